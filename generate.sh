@@ -1,6 +1,8 @@
 # generate code
-protoc web_log/web_log_pb/web_log.proto --go_out=plugins=grpc:.
+protoc -I . -I third_party/googleapis web_log/web_log_pb/web_log.proto --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:.
+# REST/JSON gateway and its OpenAPI spec
+protoc -I . -I third_party/googleapis web_log/web_log_pb/web_log.proto --grpc-gateway_out=paths=source_relative:. --openapiv2_out=.
 
 # path setup
 export GOPATH=$HOME/go
-PATH=$PATH:$GOPATH/bin
\ No newline at end of file
+PATH=$PATH:$GOPATH/bin
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"grpc_web_log/web_log/web_log_pb"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// gRPC-Web frame flag of the trailers
+const trailerFlag = 0x80
+
+// Call a unary RPC over HTTP/1.1 with the gRPC-Web text encoding, as a browser does
+func grpcWebCall(baseURL string, method string, req proto.Message, res proto.Message) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, 5+len(body))
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(body)))
+	copy(frame[5:], body)
+
+	httpReq, err := http.NewRequest("POST", strings.TrimSuffix(baseURL, "/")+"/web_log.WebLogService/"+method,
+		strings.NewReader(base64.StdEncoding.EncodeToString(frame)))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc-web-text")
+	httpReq.Header.Set("Accept", "application/grpc-web-text")
+	httpReq.Header.Set("X-Grpc-Web", "1")
+	if token := os.Getenv("WEBLOG_TOKEN"); token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpRes, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("gRPC-Web call failed with HTTP status %s", httpRes.Status)
+	}
+	encoded, err := ioutil.ReadAll(httpRes.Body)
+	if err != nil {
+		return err
+	}
+	data, err := decodeGRPCWebText(encoded)
+	if err != nil {
+		return err
+	}
+
+	// a trailers-only response puts the status in the headers
+	trailers := textproto.MIMEHeader(httpRes.Header)
+	for len(data) >= 5 {
+		flag := data[0]
+		length := int(binary.BigEndian.Uint32(data[1:5]))
+		if len(data) < 5+length {
+			return errors.New("truncated gRPC-Web frame")
+		}
+		payload := data[5 : 5+length]
+		data = data[5+length:]
+		if flag&trailerFlag != 0 {
+			for _, line := range strings.Split(string(payload), "\r\n") {
+				if i := strings.IndexByte(line, ':'); i > 0 {
+					trailers.Set(strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]))
+				}
+			}
+			continue
+		}
+		if err := proto.Unmarshal(payload, res); err != nil {
+			return err
+		}
+	}
+
+	code, err := strconv.Atoi(trailers.Get("Grpc-Status"))
+	if err != nil {
+		return errors.New("gRPC-Web response has no grpc-status")
+	}
+	if codes.Code(code) != codes.OK {
+		return status.Error(codes.Code(code), trailers.Get("Grpc-Message"))
+	}
+	return nil
+}
+
+// Decode a text encoded body, which may be several padded base64 chunks
+func decodeGRPCWebText(encoded []byte) ([]byte, error) {
+	text := strings.Join(strings.Fields(string(encoded)), "")
+	var data []byte
+	for len(text) >= 4 {
+		chunk, err := base64.StdEncoding.DecodeString(text[:4])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+		text = text[4:]
+	}
+	if text != "" {
+		return nil, errors.New("malformed gRPC-Web text body")
+	}
+	return data, nil
+}
+
+// List the articles and update one through the gRPC-Web listener
+func doGRPCWeb(baseURL string) {
+	fmt.Println("Starting to do gRPC-Web RPCs on", baseURL)
+
+	all := &web_log_pb.GetAllArticlesResponse{}
+	if err := grpcWebCall(baseURL, "GetAllArticles", &web_log_pb.GetAllArticlesRequest{}, all); err != nil {
+		log.Fatalf("Error while calling GetAllArticles over gRPC-Web: %v", err)
+	}
+	log.Printf("Response from GetAllArticles: %v", all.GetResult())
+
+	req := &web_log_pb.UpdateSpecifiedArticleRequest{
+		ArticleID: updateArticleID,
+		Title:     proto.String("update title"),
+		Content:   proto.String("update content"),
+	}
+	updated := &web_log_pb.UpdateSpecifiedArticleResponse{}
+	if err := grpcWebCall(baseURL, "UpdateSpecifiedArticle", req, updated); err != nil {
+		log.Fatalf("Error while calling UpdateSpecifiedArticle over gRPC-Web: %v", err)
+	}
+	log.Printf("Response from UpdateSpecifiedArticle: %v", updated.GetResult())
+}
@@ -8,10 +8,36 @@ import (
 	"grpc_web_log/web_log/web_log_pb"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// bearerToken sends an API key or a JWT with every RPC
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// the server listens without TLS
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
 // text files with articles
 const txtFile = "conf/articles.txt"
 
@@ -20,17 +46,131 @@ var specifiedArticleID = "dff4bf31-6423-779d-65dc-72c67c96e5ef"
 var updateArticleID = "925ee90d-fdf1-9e1a-3146-3fb4331b9023"
 var removeArticleID = "7a566f6f-f931-609e-425c-d5b48fa7e4f5"
 
+// Set up tracing from WEBLOG_TRACE_EXPORTER (stdout or otlp), the otlp endpoint comes from OTEL_EXPORTER_OTLP_ENDPOINT
+func initTracing() func(context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch os.Getenv("WEBLOG_TRACE_EXPORTER") {
+	case "stdout":
+		exporter, err = stdouttrace.New()
+	case "otlp":
+		exporter, err = otlptracegrpc.New(context.Background())
+	default:
+		return func(context.Context) error { return nil }
+	}
+	if err != nil {
+		log.Fatalf("could not create trace exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName("web_log_client"))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
+
 func main() {
 	fmt.Println("Hello I'm a client")
-	conn, err := grpc.Dial("127.0.0.1:50051", grpc.WithInsecure())
+	shutdownTracing := initTracing()
+	defer shutdownTracing(context.Background())
+
+	// web_log_client grpcweb [url]
+	if len(os.Args) > 1 && os.Args[1] == "grpcweb" {
+		baseURL := "http://127.0.0.1:8081"
+		if len(os.Args) > 2 {
+			baseURL = os.Args[2]
+		}
+		doGRPCWeb(baseURL)
+		return
+	}
+
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+	// token from conf.json apiKeys or a JWT signed with jwtSecret
+	if token := os.Getenv("WEBLOG_TOKEN"); token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+	conn, err := grpc.Dial("127.0.0.1:50051", opts...)
 
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
 	defer conn.Close()
 
+	// web_log_client health [service]
+	if len(os.Args) > 1 && os.Args[1] == "health" {
+		service := ""
+		if len(os.Args) > 2 {
+			service = os.Args[2]
+		}
+		doHealthCheck(conn, service)
+		return
+	}
+
 	c := web_log_pb.NewWebLogServiceClient(conn)
 
+	// web_log_client render articleID
+	if len(os.Args) > 2 && os.Args[1] == "render" {
+		doRenderArticle(c, os.Args[2])
+		return
+	}
+
+	// web_log_client tags
+	if len(os.Args) > 1 && os.Args[1] == "tags" {
+		doListTags(c)
+		return
+	}
+
+	// web_log_client list [tag]
+	if len(os.Args) > 1 && os.Args[1] == "list" {
+		tag := ""
+		if len(os.Args) > 2 {
+			tag = os.Args[2]
+		}
+		doListArticles(c, tag)
+		return
+	}
+
+	// web_log_client status articleID draft|in_review|published|archived [publishAt]
+	if len(os.Args) > 3 && os.Args[1] == "status" {
+		publishAt := ""
+		if len(os.Args) > 4 {
+			publishAt = os.Args[4]
+		}
+		doSetArticleStatus(c, os.Args[2], os.Args[3], publishAt)
+		return
+	}
+
+	// web_log_client watch [tag]
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		tag := ""
+		if len(os.Args) > 2 {
+			tag = os.Args[2]
+		}
+		doWatchArticles(c, tag)
+		return
+	}
+
+	cc := web_log_pb.NewCommentServiceClient(conn)
+
+	// web_log_client comment articleID content [parentID]
+	if len(os.Args) > 3 && os.Args[1] == "comment" {
+		parentID := ""
+		if len(os.Args) > 4 {
+			parentID = os.Args[4]
+		}
+		doAddComment(cc, os.Args[2], os.Args[3], parentID)
+		return
+	}
+
+	// web_log_client comments articleID
+	if len(os.Args) > 2 && os.Args[1] == "comments" {
+		doListComments(cc, os.Args[2])
+		return
+	}
+
 	doArticleStreaming(c)
 
 	doAllArticles(c)
@@ -127,8 +267,8 @@ func doUpdateSpecifiedArticle(c web_log_pb.WebLogServiceClient) {
 	fmt.Println("\nStarting to do a Update Specified Article RPC...")
 	req := &web_log_pb.UpdateSpecifiedArticleRequest{
 		ArticleID: updateArticleID,
-		Title:     "update title",
-		Content:   "update content",
+		Title:     proto.String("update title"),
+		Content:   proto.String("update content"),
 	}
 	fmt.Println("req", req)
 	res, err := c.UpdateSpecifiedArticle(context.Background(), req)
@@ -151,3 +291,162 @@ func doRemoveSpecifiedArticle(c web_log_pb.WebLogServiceClient) {
 	}
 	log.Printf("Response from RemoveSpecifiedArticle: %v\n", res.Result)
 }
+
+// gRPC client for doRenderArticle: request the sanitized HTML, table of contents and excerpt of an article
+func doRenderArticle(c web_log_pb.WebLogServiceClient, articleID string) {
+	fmt.Println("\nStarting to do a Render Article RPC...")
+	req := &web_log_pb.RenderArticleRequest{
+		ArticleID: articleID,
+	}
+	res, err := c.RenderArticle(context.Background(), req)
+	if err != nil {
+		log.Fatalf("Error while calling Render Article Rpc: %v", err)
+	}
+	fmt.Printf("%v (version %v)\n", res.Title, res.Version)
+	for _, entry := range res.Toc {
+		fmt.Printf("%v- %v #%v\n", strings.Repeat("  ", int(entry.Level)-1), entry.Text, entry.Id)
+	}
+	fmt.Printf("excerpt: %v\n\n%v\n", res.Excerpt, res.Html)
+}
+
+// gRPC client for doListTags: request every tag and category with its number of articles
+func doListTags(c web_log_pb.WebLogServiceClient) {
+	fmt.Println("\nStarting to do a List Tags RPC...")
+	res, err := c.ListTags(context.Background(), &web_log_pb.ListTagsRequest{})
+	if err != nil {
+		log.Fatalf("Error while calling List Tags Rpc: %v", err)
+	}
+	fmt.Println("tags:")
+	for _, tag := range res.Tags {
+		fmt.Printf("  %v (%v)\n", tag.Name, tag.Count)
+	}
+	fmt.Println("categories:")
+	for _, category := range res.Categories {
+		fmt.Printf("  %v (%v)\n", category.Name, category.Count)
+	}
+}
+
+// gRPC client for doListArticles: request the articles with a tag, every article when the tag is empty, page by page
+func doListArticles(c web_log_pb.WebLogServiceClient, tag string) {
+	fmt.Println("\nStarting to do a List Articles RPC...")
+	req := &web_log_pb.ListArticlesRequest{Tag: tag}
+	for {
+		res, err := c.ListArticles(context.Background(), req)
+		if err != nil {
+			log.Fatalf("Error while calling List Articles Rpc: %v", err)
+		}
+		for _, article := range res.Articles {
+			fmt.Printf("%v %v %v %v\n", article.ArticleID, article.Title, article.Category, article.Tags)
+		}
+		if res.NextPageToken == "" {
+			return
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+// gRPC client for doSetArticleStatus: move an article to another status, and schedule it when publishAt is set
+func doSetArticleStatus(c web_log_pb.WebLogServiceClient, articleID string, articleStatus string, publishAt string) {
+	fmt.Println("\nStarting to do a Set Article Status RPC...")
+	req := &web_log_pb.SetArticleStatusRequest{
+		ArticleID: articleID,
+		Status:    web_log_pb.ArticleStatus(web_log_pb.ArticleStatus_value[strings.ToUpper(articleStatus)]),
+	}
+	if publishAt != "" {
+		req.PublishAt = &publishAt
+	}
+	res, err := c.SetArticleStatus(context.Background(), req)
+	if err != nil {
+		log.Fatalf("Error while calling Set Article Status Rpc: %v", err)
+	}
+	log.Printf("Response from SetArticleStatus: %v %v %v (version %v)\n", res.ArticleID, res.Status, res.PublishAt, res.Version)
+}
+
+// gRPC client for doWatchArticles: print the changes of the articles with a tag, of every article when the tag is empty,
+// and reconnect from the last cursor when the stream breaks
+func doWatchArticles(c web_log_pb.WebLogServiceClient, tag string) {
+	fmt.Println("\nStarting to do a Watch Articles RPC...")
+	req := &web_log_pb.WatchArticlesRequest{}
+	if tag != "" {
+		req.Tags = []string{tag}
+	}
+	for {
+		stream, err := c.WatchArticles(context.Background(), req)
+		if err != nil {
+			log.Fatalf("Error while calling Watch Articles Rpc: %v", err)
+		}
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if code := status.Code(err); code != codes.Unavailable && code != codes.ResourceExhausted {
+					log.Fatalf("Error while reading Watch Articles stream: %v", err)
+				}
+				log.Printf("Watch Articles stream broke, resuming: %v\n", err)
+				time.Sleep(time.Second)
+				break
+			}
+			req.Cursor = event.Cursor
+			if event.Type == web_log_pb.ArticleEventType_HEARTBEAT {
+				continue
+			}
+			fmt.Printf("%v %v %v version %v\n", event.Time, event.Type, event.ArticleID, event.Version)
+		}
+	}
+}
+
+// gRPC client for doAddComment: comment on an article, or reply to the comment parentID
+func doAddComment(c web_log_pb.CommentServiceClient, articleID string, content string, parentID string) {
+	fmt.Println("\nStarting to do a Add Comment RPC...")
+	req := &web_log_pb.AddCommentRequest{
+		ArticleID: articleID,
+		ParentID:  parentID,
+		Content:   content,
+	}
+	res, err := c.AddComment(context.Background(), req)
+	if err != nil {
+		log.Fatalf("Error while calling Add Comment Rpc: %v", err)
+	}
+	log.Printf("Response from AddComment: %v %v\n", res.CommentID, res.Status)
+}
+
+// gRPC client for doListComments: request the comment threads of an article, page by page
+func doListComments(c web_log_pb.CommentServiceClient, articleID string) {
+	fmt.Println("\nStarting to do a List Comments RPC...")
+	var printThread func(comment *web_log_pb.Comment, depth int)
+	printThread = func(comment *web_log_pb.Comment, depth int) {
+		fmt.Printf("%v%v %v [%v] %v\n", strings.Repeat("  ", depth), comment.CommentID, comment.Author, comment.Status, comment.Content)
+		for _, reply := range comment.Replies {
+			printThread(reply, depth+1)
+		}
+	}
+	req := &web_log_pb.ListCommentsRequest{ArticleID: articleID}
+	for {
+		res, err := c.ListComments(context.Background(), req)
+		if err != nil {
+			log.Fatalf("Error while calling List Comments Rpc: %v", err)
+		}
+		for _, comment := range res.Comments {
+			printThread(comment, 0)
+		}
+		if res.NextPageToken == "" {
+			return
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+// gRPC client for doHealthCheck: ask the server whether a service is serving
+func doHealthCheck(conn *grpc.ClientConn, service string) {
+	fmt.Println("\nStarting to do a Health Check RPC...")
+	req := &healthpb.HealthCheckRequest{
+		Service: service,
+	}
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), req)
+	if err != nil {
+		log.Fatalf("Error while calling Health Check Rpc: %v", err)
+	}
+	fmt.Println(res.Status)
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,10 @@
+package web_log_pb
+
+import (
+	_ "embed"
+)
+
+// OpenAPISpec is the OpenAPI v2 spec of the REST routes, generated from web_log.proto
+//
+//go:embed web_log.swagger.json
+var OpenAPISpec []byte
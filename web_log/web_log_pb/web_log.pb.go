@@ -1,750 +1,3493 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.6.1
 // source: web_log/web_log_pb/web_log.proto
 
 package web_log_pb
 
 import (
-	context "context"
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-	math "math"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+type ArticleStatus int32
 
-type SaveAllArticlesRequest struct {
-	Article              string   `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+const (
+	// unchanged in a request
+	ArticleStatus_ARTICLE_STATUS_UNSPECIFIED ArticleStatus = 0
+	ArticleStatus_DRAFT                      ArticleStatus = 1
+	ArticleStatus_IN_REVIEW                  ArticleStatus = 2
+	// the only status readers see
+	ArticleStatus_PUBLISHED ArticleStatus = 3
+	ArticleStatus_ARCHIVED  ArticleStatus = 4
+)
+
+// Enum value maps for ArticleStatus.
+var (
+	ArticleStatus_name = map[int32]string{
+		0: "ARTICLE_STATUS_UNSPECIFIED",
+		1: "DRAFT",
+		2: "IN_REVIEW",
+		3: "PUBLISHED",
+		4: "ARCHIVED",
+	}
+	ArticleStatus_value = map[string]int32{
+		"ARTICLE_STATUS_UNSPECIFIED": 0,
+		"DRAFT":                      1,
+		"IN_REVIEW":                  2,
+		"PUBLISHED":                  3,
+		"ARCHIVED":                   4,
+	}
+)
+
+func (x ArticleStatus) Enum() *ArticleStatus {
+	p := new(ArticleStatus)
+	*p = x
+	return p
 }
 
-func (m *SaveAllArticlesRequest) Reset()         { *m = SaveAllArticlesRequest{} }
-func (m *SaveAllArticlesRequest) String() string { return proto.CompactTextString(m) }
-func (*SaveAllArticlesRequest) ProtoMessage()    {}
-func (*SaveAllArticlesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{0}
+func (x ArticleStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArticleStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_web_log_web_log_pb_web_log_proto_enumTypes[0].Descriptor()
+}
+
+func (ArticleStatus) Type() protoreflect.EnumType {
+	return &file_web_log_web_log_pb_web_log_proto_enumTypes[0]
+}
+
+func (x ArticleStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArticleStatus.Descriptor instead.
+func (ArticleStatus) EnumDescriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{0}
+}
+
+type ArticleEventType int32
+
+const (
+	ArticleEventType_ARTICLE_EVENT_TYPE_UNSPECIFIED ArticleEventType = 0
+	ArticleEventType_CREATED                        ArticleEventType = 1
+	ArticleEventType_UPDATED                        ArticleEventType = 2
+	ArticleEventType_DELETED                        ArticleEventType = 3
+	// no article changed for the watcher, only the cursor moved past the events it does not watch
+	ArticleEventType_HEARTBEAT ArticleEventType = 4
+)
+
+// Enum value maps for ArticleEventType.
+var (
+	ArticleEventType_name = map[int32]string{
+		0: "ARTICLE_EVENT_TYPE_UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+		4: "HEARTBEAT",
+	}
+	ArticleEventType_value = map[string]int32{
+		"ARTICLE_EVENT_TYPE_UNSPECIFIED": 0,
+		"CREATED":                        1,
+		"UPDATED":                        2,
+		"DELETED":                        3,
+		"HEARTBEAT":                      4,
+	}
+)
+
+func (x ArticleEventType) Enum() *ArticleEventType {
+	p := new(ArticleEventType)
+	*p = x
+	return p
 }
 
-func (m *SaveAllArticlesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SaveAllArticlesRequest.Unmarshal(m, b)
+func (x ArticleEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
-func (m *SaveAllArticlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SaveAllArticlesRequest.Marshal(b, m, deterministic)
+
+func (ArticleEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_web_log_web_log_pb_web_log_proto_enumTypes[1].Descriptor()
 }
-func (m *SaveAllArticlesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SaveAllArticlesRequest.Merge(m, src)
+
+func (ArticleEventType) Type() protoreflect.EnumType {
+	return &file_web_log_web_log_pb_web_log_proto_enumTypes[1]
 }
-func (m *SaveAllArticlesRequest) XXX_Size() int {
-	return xxx_messageInfo_SaveAllArticlesRequest.Size(m)
+
+func (x ArticleEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
-func (m *SaveAllArticlesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SaveAllArticlesRequest.DiscardUnknown(m)
+
+// Deprecated: Use ArticleEventType.Descriptor instead.
+func (ArticleEventType) EnumDescriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{1}
 }
 
-var xxx_messageInfo_SaveAllArticlesRequest proto.InternalMessageInfo
+type ModerationStatus int32
+
+const (
+	// not yet seen by an editor, only shown to its author and editors
+	ModerationStatus_PENDING  ModerationStatus = 0
+	ModerationStatus_APPROVED ModerationStatus = 1
+	ModerationStatus_SPAM     ModerationStatus = 2
+)
 
-func (m *SaveAllArticlesRequest) GetArticle() string {
-	if m != nil {
-		return m.Article
+// Enum value maps for ModerationStatus.
+var (
+	ModerationStatus_name = map[int32]string{
+		0: "PENDING",
+		1: "APPROVED",
+		2: "SPAM",
 	}
-	return ""
+	ModerationStatus_value = map[string]int32{
+		"PENDING":  0,
+		"APPROVED": 1,
+		"SPAM":     2,
+	}
+)
+
+func (x ModerationStatus) Enum() *ModerationStatus {
+	p := new(ModerationStatus)
+	*p = x
+	return p
 }
 
-type SaveAllArticlesResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x ModerationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (m *SaveAllArticlesResponse) Reset()         { *m = SaveAllArticlesResponse{} }
-func (m *SaveAllArticlesResponse) String() string { return proto.CompactTextString(m) }
-func (*SaveAllArticlesResponse) ProtoMessage()    {}
-func (*SaveAllArticlesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{1}
+func (ModerationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_web_log_web_log_pb_web_log_proto_enumTypes[2].Descriptor()
+}
+
+func (ModerationStatus) Type() protoreflect.EnumType {
+	return &file_web_log_web_log_pb_web_log_proto_enumTypes[2]
 }
 
-func (m *SaveAllArticlesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SaveAllArticlesResponse.Unmarshal(m, b)
+func (x ModerationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
-func (m *SaveAllArticlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SaveAllArticlesResponse.Marshal(b, m, deterministic)
+
+// Deprecated: Use ModerationStatus.Descriptor instead.
+func (ModerationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{2}
 }
-func (m *SaveAllArticlesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SaveAllArticlesResponse.Merge(m, src)
+
+type SaveAllArticlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Article string `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
+	// plain, markdown or html, plain when empty
+	ContentFormat string   `protobuf:"bytes,2,opt,name=contentFormat,proto3" json:"contentFormat,omitempty"`
+	Tags          []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category      string   `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// when any request of the stream sets it, only the articles with one of these tags are saved
+	IncludeTags []string `protobuf:"bytes,5,rep,name=includeTags,proto3" json:"includeTags,omitempty"`
+	// published when unspecified, or draft when publishAt is set
+	Status ArticleStatus `protobuf:"varint,6,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	// RFC 3339 time a draft or in_review article is published at
+	PublishAt string `protobuf:"bytes,7,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+}
+
+func (x *SaveAllArticlesRequest) Reset() {
+	*x = SaveAllArticlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *SaveAllArticlesResponse) XXX_Size() int {
-	return xxx_messageInfo_SaveAllArticlesResponse.Size(m)
+
+func (x *SaveAllArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *SaveAllArticlesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SaveAllArticlesResponse.DiscardUnknown(m)
+
+func (*SaveAllArticlesRequest) ProtoMessage() {}
+
+func (x *SaveAllArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_SaveAllArticlesResponse proto.InternalMessageInfo
+// Deprecated: Use SaveAllArticlesRequest.ProtoReflect.Descriptor instead.
+func (*SaveAllArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{0}
+}
 
-func (m *SaveAllArticlesResponse) GetResult() string {
-	if m != nil {
-		return m.Result
+func (x *SaveAllArticlesRequest) GetArticle() string {
+	if x != nil {
+		return x.Article
 	}
 	return ""
 }
 
-type GetAllArticlesRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *SaveAllArticlesRequest) GetContentFormat() string {
+	if x != nil {
+		return x.ContentFormat
+	}
+	return ""
 }
 
-func (m *GetAllArticlesRequest) Reset()         { *m = GetAllArticlesRequest{} }
-func (m *GetAllArticlesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetAllArticlesRequest) ProtoMessage()    {}
-func (*GetAllArticlesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{2}
+func (x *SaveAllArticlesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
 }
 
-func (m *GetAllArticlesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAllArticlesRequest.Unmarshal(m, b)
+func (x *SaveAllArticlesRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
 }
-func (m *GetAllArticlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAllArticlesRequest.Marshal(b, m, deterministic)
+
+func (x *SaveAllArticlesRequest) GetIncludeTags() []string {
+	if x != nil {
+		return x.IncludeTags
+	}
+	return nil
 }
-func (m *GetAllArticlesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAllArticlesRequest.Merge(m, src)
+
+func (x *SaveAllArticlesRequest) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
 }
-func (m *GetAllArticlesRequest) XXX_Size() int {
-	return xxx_messageInfo_GetAllArticlesRequest.Size(m)
+
+func (x *SaveAllArticlesRequest) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
 }
-func (m *GetAllArticlesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAllArticlesRequest.DiscardUnknown(m)
+
+type SaveAllArticlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-var xxx_messageInfo_GetAllArticlesRequest proto.InternalMessageInfo
+func (x *SaveAllArticlesResponse) Reset() {
+	*x = SaveAllArticlesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-type GetAllArticlesResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *SaveAllArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *GetAllArticlesResponse) Reset()         { *m = GetAllArticlesResponse{} }
-func (m *GetAllArticlesResponse) String() string { return proto.CompactTextString(m) }
-func (*GetAllArticlesResponse) ProtoMessage()    {}
-func (*GetAllArticlesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{3}
+func (*SaveAllArticlesResponse) ProtoMessage() {}
+
+func (x *SaveAllArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetAllArticlesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAllArticlesResponse.Unmarshal(m, b)
+// Deprecated: Use SaveAllArticlesResponse.ProtoReflect.Descriptor instead.
+func (*SaveAllArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{1}
 }
-func (m *GetAllArticlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAllArticlesResponse.Marshal(b, m, deterministic)
+
+func (x *SaveAllArticlesResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
 }
-func (m *GetAllArticlesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAllArticlesResponse.Merge(m, src)
+
+type GetAllArticlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
-func (m *GetAllArticlesResponse) XXX_Size() int {
-	return xxx_messageInfo_GetAllArticlesResponse.Size(m)
+
+func (x *GetAllArticlesRequest) Reset() {
+	*x = GetAllArticlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetAllArticlesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAllArticlesResponse.DiscardUnknown(m)
+
+func (x *GetAllArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_GetAllArticlesResponse proto.InternalMessageInfo
+func (*GetAllArticlesRequest) ProtoMessage() {}
 
-func (m *GetAllArticlesResponse) GetResult() string {
-	if m != nil {
-		return m.Result
+func (x *GetAllArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type GetSpecifiedArticleRequest struct {
-	ArticleID            string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Deprecated: Use GetAllArticlesRequest.ProtoReflect.Descriptor instead.
+func (*GetAllArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{2}
 }
 
-func (m *GetSpecifiedArticleRequest) Reset()         { *m = GetSpecifiedArticleRequest{} }
-func (m *GetSpecifiedArticleRequest) String() string { return proto.CompactTextString(m) }
-func (*GetSpecifiedArticleRequest) ProtoMessage()    {}
-func (*GetSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{4}
+type GetAllArticlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-func (m *GetSpecifiedArticleRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSpecifiedArticleRequest.Unmarshal(m, b)
+func (x *GetAllArticlesResponse) Reset() {
+	*x = GetAllArticlesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetSpecifiedArticleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSpecifiedArticleRequest.Marshal(b, m, deterministic)
+
+func (x *GetAllArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *GetSpecifiedArticleRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSpecifiedArticleRequest.Merge(m, src)
+
+func (*GetAllArticlesResponse) ProtoMessage() {}
+
+func (x *GetAllArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *GetSpecifiedArticleRequest) XXX_Size() int {
-	return xxx_messageInfo_GetSpecifiedArticleRequest.Size(m)
+
+// Deprecated: Use GetAllArticlesResponse.ProtoReflect.Descriptor instead.
+func (*GetAllArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{3}
 }
-func (m *GetSpecifiedArticleRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSpecifiedArticleRequest.DiscardUnknown(m)
+
+func (x *GetAllArticlesResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
 }
 
-var xxx_messageInfo_GetSpecifiedArticleRequest proto.InternalMessageInfo
+type GetSpecifiedArticleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+}
 
-func (m *GetSpecifiedArticleRequest) GetArticleID() string {
-	if m != nil {
-		return m.ArticleID
+func (x *GetSpecifiedArticleRequest) Reset() {
+	*x = GetSpecifiedArticleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-type GetSpecifiedArticleResponse struct {
-	ArticleID            string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *GetSpecifiedArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *GetSpecifiedArticleResponse) Reset()         { *m = GetSpecifiedArticleResponse{} }
-func (m *GetSpecifiedArticleResponse) String() string { return proto.CompactTextString(m) }
-func (*GetSpecifiedArticleResponse) ProtoMessage()    {}
-func (*GetSpecifiedArticleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{5}
+func (*GetSpecifiedArticleRequest) ProtoMessage() {}
+
+func (x *GetSpecifiedArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpecifiedArticleRequest.ProtoReflect.Descriptor instead.
+func (*GetSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{4}
 }
 
-func (m *GetSpecifiedArticleResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSpecifiedArticleResponse.Unmarshal(m, b)
+func (x *GetSpecifiedArticleRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
 }
-func (m *GetSpecifiedArticleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSpecifiedArticleResponse.Marshal(b, m, deterministic)
+
+type GetSpecifiedArticleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID     string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Title         string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ContentFormat string `protobuf:"bytes,4,opt,name=contentFormat,proto3" json:"contentFormat,omitempty"`
+	// incremented on every update
+	Version  int64         `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Tags     []string      `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category string        `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Status   ArticleStatus `protobuf:"varint,8,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	// RFC 3339, empty when not scheduled
+	PublishAt string `protobuf:"bytes,9,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+}
+
+func (x *GetSpecifiedArticleResponse) Reset() {
+	*x = GetSpecifiedArticleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *GetSpecifiedArticleResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSpecifiedArticleResponse.Merge(m, src)
+
+func (x *GetSpecifiedArticleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *GetSpecifiedArticleResponse) XXX_Size() int {
-	return xxx_messageInfo_GetSpecifiedArticleResponse.Size(m)
+
+func (*GetSpecifiedArticleResponse) ProtoMessage() {}
+
+func (x *GetSpecifiedArticleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *GetSpecifiedArticleResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSpecifiedArticleResponse.DiscardUnknown(m)
+
+// Deprecated: Use GetSpecifiedArticleResponse.ProtoReflect.Descriptor instead.
+func (*GetSpecifiedArticleResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{5}
 }
 
-var xxx_messageInfo_GetSpecifiedArticleResponse proto.InternalMessageInfo
+func (x *GetSpecifiedArticleResponse) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
 
-func (m *GetSpecifiedArticleResponse) GetArticleID() string {
-	if m != nil {
-		return m.ArticleID
+func (x *GetSpecifiedArticleResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-func (m *GetSpecifiedArticleResponse) GetTitle() string {
-	if m != nil {
-		return m.Title
+func (x *GetSpecifiedArticleResponse) GetContent() string {
+	if x != nil {
+		return x.Content
 	}
 	return ""
 }
 
-func (m *GetSpecifiedArticleResponse) GetContent() string {
-	if m != nil {
-		return m.Content
+func (x *GetSpecifiedArticleResponse) GetContentFormat() string {
+	if x != nil {
+		return x.ContentFormat
 	}
 	return ""
 }
 
-type UpdateSpecifiedArticleRequest struct {
-	ArticleID            string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *GetSpecifiedArticleResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (m *UpdateSpecifiedArticleRequest) Reset()         { *m = UpdateSpecifiedArticleRequest{} }
-func (m *UpdateSpecifiedArticleRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateSpecifiedArticleRequest) ProtoMessage()    {}
-func (*UpdateSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{6}
+func (x *GetSpecifiedArticleResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetSpecifiedArticleResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
 }
 
-func (m *UpdateSpecifiedArticleRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateSpecifiedArticleRequest.Unmarshal(m, b)
+func (x *GetSpecifiedArticleResponse) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
 }
-func (m *UpdateSpecifiedArticleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateSpecifiedArticleRequest.Marshal(b, m, deterministic)
+
+func (x *GetSpecifiedArticleResponse) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
 }
-func (m *UpdateSpecifiedArticleRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateSpecifiedArticleRequest.Merge(m, src)
+
+type UpdateSpecifiedArticleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// only the fields that are set are changed
+	Title   *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Content *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	// plain, markdown or html
+	ContentFormat *string `protobuf:"bytes,4,opt,name=contentFormat,proto3,oneof" json:"contentFormat,omitempty"`
+	// unchanged when not set, removed when set to ""
+	Category *string `protobuf:"bytes,5,opt,name=category,proto3,oneof" json:"category,omitempty"`
+}
+
+func (x *UpdateSpecifiedArticleRequest) Reset() {
+	*x = UpdateSpecifiedArticleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *UpdateSpecifiedArticleRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateSpecifiedArticleRequest.Size(m)
+
+func (x *UpdateSpecifiedArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *UpdateSpecifiedArticleRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateSpecifiedArticleRequest.DiscardUnknown(m)
+
+func (*UpdateSpecifiedArticleRequest) ProtoMessage() {}
+
+func (x *UpdateSpecifiedArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_UpdateSpecifiedArticleRequest proto.InternalMessageInfo
+// Deprecated: Use UpdateSpecifiedArticleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *UpdateSpecifiedArticleRequest) GetArticleID() string {
-	if m != nil {
-		return m.ArticleID
+func (x *UpdateSpecifiedArticleRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
 	return ""
 }
 
-func (m *UpdateSpecifiedArticleRequest) GetTitle() string {
-	if m != nil {
-		return m.Title
+func (x *UpdateSpecifiedArticleRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
 
-func (m *UpdateSpecifiedArticleRequest) GetContent() string {
-	if m != nil {
-		return m.Content
+func (x *UpdateSpecifiedArticleRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
 	}
 	return ""
 }
 
-type UpdateSpecifiedArticleResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *UpdateSpecifiedArticleRequest) GetContentFormat() string {
+	if x != nil && x.ContentFormat != nil {
+		return *x.ContentFormat
+	}
+	return ""
 }
 
-func (m *UpdateSpecifiedArticleResponse) Reset()         { *m = UpdateSpecifiedArticleResponse{} }
-func (m *UpdateSpecifiedArticleResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateSpecifiedArticleResponse) ProtoMessage()    {}
-func (*UpdateSpecifiedArticleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{7}
+func (x *UpdateSpecifiedArticleRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
 }
 
-func (m *UpdateSpecifiedArticleResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateSpecifiedArticleResponse.Unmarshal(m, b)
-}
-func (m *UpdateSpecifiedArticleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateSpecifiedArticleResponse.Marshal(b, m, deterministic)
+type UpdateSpecifiedArticleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 }
-func (m *UpdateSpecifiedArticleResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateSpecifiedArticleResponse.Merge(m, src)
+
+func (x *UpdateSpecifiedArticleResponse) Reset() {
+	*x = UpdateSpecifiedArticleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *UpdateSpecifiedArticleResponse) XXX_Size() int {
-	return xxx_messageInfo_UpdateSpecifiedArticleResponse.Size(m)
+
+func (x *UpdateSpecifiedArticleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *UpdateSpecifiedArticleResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateSpecifiedArticleResponse.DiscardUnknown(m)
+
+func (*UpdateSpecifiedArticleResponse) ProtoMessage() {}
+
+func (x *UpdateSpecifiedArticleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_UpdateSpecifiedArticleResponse proto.InternalMessageInfo
+// Deprecated: Use UpdateSpecifiedArticleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSpecifiedArticleResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{7}
+}
 
-func (m *UpdateSpecifiedArticleResponse) GetResult() string {
-	if m != nil {
-		return m.Result
+func (x *UpdateSpecifiedArticleResponse) GetResult() string {
+	if x != nil {
+		return x.Result
 	}
 	return ""
 }
 
 type RemoveSpecifiedArticleRequest struct {
-	ArticleID            string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *RemoveSpecifiedArticleRequest) Reset()         { *m = RemoveSpecifiedArticleRequest{} }
-func (m *RemoveSpecifiedArticleRequest) String() string { return proto.CompactTextString(m) }
-func (*RemoveSpecifiedArticleRequest) ProtoMessage()    {}
-func (*RemoveSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{8}
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
 }
 
-func (m *RemoveSpecifiedArticleRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveSpecifiedArticleRequest.Unmarshal(m, b)
-}
-func (m *RemoveSpecifiedArticleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveSpecifiedArticleRequest.Marshal(b, m, deterministic)
-}
-func (m *RemoveSpecifiedArticleRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveSpecifiedArticleRequest.Merge(m, src)
+func (x *RemoveSpecifiedArticleRequest) Reset() {
+	*x = RemoveSpecifiedArticleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *RemoveSpecifiedArticleRequest) XXX_Size() int {
-	return xxx_messageInfo_RemoveSpecifiedArticleRequest.Size(m)
+
+func (x *RemoveSpecifiedArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *RemoveSpecifiedArticleRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveSpecifiedArticleRequest.DiscardUnknown(m)
+
+func (*RemoveSpecifiedArticleRequest) ProtoMessage() {}
+
+func (x *RemoveSpecifiedArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_RemoveSpecifiedArticleRequest proto.InternalMessageInfo
+// Deprecated: Use RemoveSpecifiedArticleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSpecifiedArticleRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{8}
+}
 
-func (m *RemoveSpecifiedArticleRequest) GetArticleID() string {
-	if m != nil {
-		return m.ArticleID
+func (x *RemoveSpecifiedArticleRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
 	return ""
 }
 
 type RemoveSpecifiedArticleResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *RemoveSpecifiedArticleResponse) Reset() {
+	*x = RemoveSpecifiedArticleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *RemoveSpecifiedArticleResponse) Reset()         { *m = RemoveSpecifiedArticleResponse{} }
-func (m *RemoveSpecifiedArticleResponse) String() string { return proto.CompactTextString(m) }
-func (*RemoveSpecifiedArticleResponse) ProtoMessage()    {}
+func (x *RemoveSpecifiedArticleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSpecifiedArticleResponse) ProtoMessage() {}
+
+func (x *RemoveSpecifiedArticleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSpecifiedArticleResponse.ProtoReflect.Descriptor instead.
 func (*RemoveSpecifiedArticleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_306d5679ce324a28, []int{9}
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{9}
 }
 
-func (m *RemoveSpecifiedArticleResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveSpecifiedArticleResponse.Unmarshal(m, b)
+func (x *RemoveSpecifiedArticleResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
 }
-func (m *RemoveSpecifiedArticleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveSpecifiedArticleResponse.Marshal(b, m, deterministic)
+
+type RenderArticleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
 }
-func (m *RemoveSpecifiedArticleResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveSpecifiedArticleResponse.Merge(m, src)
+
+func (x *RenderArticleRequest) Reset() {
+	*x = RenderArticleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *RemoveSpecifiedArticleResponse) XXX_Size() int {
-	return xxx_messageInfo_RemoveSpecifiedArticleResponse.Size(m)
+
+func (x *RenderArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *RemoveSpecifiedArticleResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveSpecifiedArticleResponse.DiscardUnknown(m)
+
+func (*RenderArticleRequest) ProtoMessage() {}
+
+func (x *RenderArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_RemoveSpecifiedArticleResponse proto.InternalMessageInfo
+// Deprecated: Use RenderArticleRequest.ProtoReflect.Descriptor instead.
+func (*RenderArticleRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{10}
+}
 
-func (m *RemoveSpecifiedArticleResponse) GetResult() string {
-	if m != nil {
-		return m.Result
+func (x *RenderArticleRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*SaveAllArticlesRequest)(nil), "web_log.SaveAllArticlesRequest")
-	proto.RegisterType((*SaveAllArticlesResponse)(nil), "web_log.SaveAllArticlesResponse")
-	proto.RegisterType((*GetAllArticlesRequest)(nil), "web_log.GetAllArticlesRequest")
-	proto.RegisterType((*GetAllArticlesResponse)(nil), "web_log.GetAllArticlesResponse")
-	proto.RegisterType((*GetSpecifiedArticleRequest)(nil), "web_log.GetSpecifiedArticleRequest")
-	proto.RegisterType((*GetSpecifiedArticleResponse)(nil), "web_log.GetSpecifiedArticleResponse")
-	proto.RegisterType((*UpdateSpecifiedArticleRequest)(nil), "web_log.UpdateSpecifiedArticleRequest")
-	proto.RegisterType((*UpdateSpecifiedArticleResponse)(nil), "web_log.UpdateSpecifiedArticleResponse")
-	proto.RegisterType((*RemoveSpecifiedArticleRequest)(nil), "web_log.RemoveSpecifiedArticleRequest")
-	proto.RegisterType((*RemoveSpecifiedArticleResponse)(nil), "web_log.RemoveSpecifiedArticleResponse")
+type TocEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// heading level, 1 to 6
+	Level int32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	// id of the heading element, the anchor of the entry
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Text string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 }
 
-func init() { proto.RegisterFile("web_log/web_log_pb/web_log.proto", fileDescriptor_306d5679ce324a28) }
+func (x *TocEntry) Reset() {
+	*x = TocEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-var fileDescriptor_306d5679ce324a28 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcd, 0x4f, 0xf2, 0x40,
-	0x10, 0xc6, 0xe1, 0x25, 0x2f, 0x84, 0x89, 0x1f, 0xc9, 0xaa, 0xa5, 0xa9, 0xf2, 0x91, 0xd5, 0x28,
-	0x27, 0x54, 0xbc, 0x18, 0x13, 0x0f, 0x18, 0x13, 0x62, 0xe2, 0x89, 0xc6, 0x68, 0xbc, 0x10, 0x5a,
-	0x46, 0xd2, 0xb0, 0xb0, 0xb5, 0x5d, 0xf0, 0x9f, 0xf7, 0x60, 0xa0, 0x5b, 0xa8, 0xb0, 0x5d, 0x22,
-	0xa7, 0x76, 0x76, 0xe6, 0x99, 0xdf, 0x64, 0xf6, 0xc9, 0x42, 0xed, 0x0b, 0x9d, 0x2e, 0xe3, 0x83,
-	0x4b, 0xf9, 0xed, 0xfa, 0x4e, 0xfc, 0xdb, 0xf0, 0x03, 0x2e, 0x38, 0x29, 0xc8, 0x90, 0x36, 0xc1,
-	0xb0, 0x7b, 0x53, 0x6c, 0x31, 0xd6, 0x0a, 0x84, 0xe7, 0x32, 0x0c, 0x3b, 0xf8, 0x39, 0xc1, 0x50,
-	0x10, 0x13, 0x0a, 0xbd, 0xe8, 0xc8, 0xcc, 0xd6, 0xb2, 0xf5, 0x62, 0x27, 0x0e, 0xe9, 0x35, 0x94,
-	0xd6, 0x34, 0xa1, 0xcf, 0xc7, 0x21, 0x12, 0x03, 0xf2, 0x01, 0x86, 0x13, 0x26, 0xa4, 0x46, 0x46,
-	0xb4, 0x04, 0x47, 0x6d, 0x14, 0xeb, 0x14, 0x7a, 0x05, 0xc6, 0x6a, 0x62, 0x43, 0xab, 0x3b, 0xb0,
-	0xda, 0x28, 0x6c, 0x1f, 0x5d, 0xef, 0xc3, 0xc3, 0xbe, 0xd4, 0xc5, 0x53, 0x9f, 0x40, 0x51, 0x8e,
-	0xf9, 0xf4, 0x28, 0x85, 0xcb, 0x03, 0x3a, 0x84, 0x63, 0xa5, 0x56, 0x22, 0xb5, 0x62, 0x72, 0x08,
-	0xff, 0x85, 0x27, 0x18, 0x9a, 0xff, 0xe6, 0x99, 0x28, 0x98, 0xad, 0xc9, 0xe5, 0x63, 0x81, 0x63,
-	0x61, 0xe6, 0xa2, 0x35, 0xc9, 0x90, 0x8e, 0xa0, 0xfc, 0xe2, 0xf7, 0x7b, 0x02, 0xb7, 0x9a, 0xf5,
-	0xcf, 0xb8, 0x5b, 0xa8, 0xa4, 0xe1, 0x36, 0x6c, 0xf4, 0x1e, 0xca, 0x1d, 0x1c, 0xf1, 0xe9, 0x76,
-	0x83, 0xce, 0xc0, 0x69, 0x72, 0x3d, 0xb8, 0xf9, 0x9d, 0x83, 0xdd, 0x57, 0x74, 0x9e, 0xf9, 0xc0,
-	0xc6, 0x60, 0xea, 0xb9, 0x48, 0xde, 0x60, 0x7f, 0xc5, 0x5a, 0xa4, 0xda, 0x88, 0xad, 0xab, 0x36,
-	0xaa, 0x55, 0x4b, 0x2f, 0x88, 0xf8, 0x34, 0x53, 0xcf, 0x12, 0x1b, 0xf6, 0x7e, 0x1b, 0x8d, 0x54,
-	0x16, 0x3a, 0xa5, 0x35, 0xad, 0x6a, 0x6a, 0x3e, 0x6e, 0x4b, 0x1c, 0x38, 0x50, 0xf8, 0x89, 0x9c,
-	0x26, 0x95, 0x29, 0x4b, 0xb5, 0xce, 0xf4, 0x45, 0x0b, 0xc6, 0x10, 0x0c, 0xf5, 0xbd, 0x92, 0xf3,
-	0x45, 0x07, 0xad, 0xcf, 0xac, 0x8b, 0x8d, 0x75, 0x49, 0x98, 0xfa, 0x2e, 0x13, 0x30, 0xad, 0x57,
-	0x12, 0x30, 0xbd, 0x29, 0x68, 0xe6, 0x61, 0xe7, 0x1d, 0x96, 0x0f, 0x94, 0x93, 0x9f, 0xbf, 0x4c,
-	0x37, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf4, 0xf5, 0xca, 0xfc, 0xbd, 0x04, 0x00, 0x00,
+func (x *TocEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (*TocEntry) ProtoMessage() {}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (x *TocEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-// WebLogServiceClient is the client API for WebLogService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WebLogServiceClient interface {
-	// Client Streaming
-	SaveAllArticles(ctx context.Context, opts ...grpc.CallOption) (WebLogService_SaveAllArticlesClient, error)
-	// Unary
-	GetAllArticles(ctx context.Context, in *GetAllArticlesRequest, opts ...grpc.CallOption) (*GetAllArticlesResponse, error)
-	// Unary
-	GetSpecifiedArticle(ctx context.Context, in *GetSpecifiedArticleRequest, opts ...grpc.CallOption) (*GetSpecifiedArticleResponse, error)
-	// Unary
-	UpdateSpecifiedArticle(ctx context.Context, in *UpdateSpecifiedArticleRequest, opts ...grpc.CallOption) (*UpdateSpecifiedArticleResponse, error)
-	// Unary
-	RemoveSpecifiedArticle(ctx context.Context, in *RemoveSpecifiedArticleRequest, opts ...grpc.CallOption) (*RemoveSpecifiedArticleResponse, error)
+// Deprecated: Use TocEntry.ProtoReflect.Descriptor instead.
+func (*TocEntry) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{11}
 }
 
-type webLogServiceClient struct {
-	cc *grpc.ClientConn
+func (x *TocEntry) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
 }
 
-func NewWebLogServiceClient(cc *grpc.ClientConn) WebLogServiceClient {
-	return &webLogServiceClient{cc}
+func (x *TocEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (c *webLogServiceClient) SaveAllArticles(ctx context.Context, opts ...grpc.CallOption) (WebLogService_SaveAllArticlesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_WebLogService_serviceDesc.Streams[0], "/web_log.WebLogService/SaveAllArticles", opts...)
-	if err != nil {
-		return nil, err
+func (x *TocEntry) GetText() string {
+	if x != nil {
+		return x.Text
 	}
-	x := &webLogServiceSaveAllArticlesClient{stream}
-	return x, nil
+	return ""
 }
 
-type WebLogService_SaveAllArticlesClient interface {
-	Send(*SaveAllArticlesRequest) error
-	CloseAndRecv() (*SaveAllArticlesResponse, error)
-	grpc.ClientStream
+type RenderArticleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Title     string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// sanitized HTML of the content
+	Html string      `protobuf:"bytes,3,opt,name=html,proto3" json:"html,omitempty"`
+	Toc  []*TocEntry `protobuf:"bytes,4,rep,name=toc,proto3" json:"toc,omitempty"`
+	// plain text start of the content
+	Excerpt string `protobuf:"bytes,5,opt,name=excerpt,proto3" json:"excerpt,omitempty"`
+	Version int64  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-type webLogServiceSaveAllArticlesClient struct {
-	grpc.ClientStream
+func (x *RenderArticleResponse) Reset() {
+	*x = RenderArticleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *webLogServiceSaveAllArticlesClient) Send(m *SaveAllArticlesRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *RenderArticleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *webLogServiceSaveAllArticlesClient) CloseAndRecv() (*SaveAllArticlesResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(SaveAllArticlesResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (*RenderArticleResponse) ProtoMessage() {}
+
+func (x *RenderArticleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return m, nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderArticleResponse.ProtoReflect.Descriptor instead.
+func (*RenderArticleResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{12}
 }
 
-func (c *webLogServiceClient) GetAllArticles(ctx context.Context, in *GetAllArticlesRequest, opts ...grpc.CallOption) (*GetAllArticlesResponse, error) {
-	out := new(GetAllArticlesResponse)
-	err := c.cc.Invoke(ctx, "/web_log.WebLogService/GetAllArticles", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *RenderArticleResponse) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *webLogServiceClient) GetSpecifiedArticle(ctx context.Context, in *GetSpecifiedArticleRequest, opts ...grpc.CallOption) (*GetSpecifiedArticleResponse, error) {
-	out := new(GetSpecifiedArticleResponse)
-	err := c.cc.Invoke(ctx, "/web_log.WebLogService/GetSpecifiedArticle", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *RenderArticleResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
-	return out, nil
+	return ""
 }
 
-func (c *webLogServiceClient) UpdateSpecifiedArticle(ctx context.Context, in *UpdateSpecifiedArticleRequest, opts ...grpc.CallOption) (*UpdateSpecifiedArticleResponse, error) {
-	out := new(UpdateSpecifiedArticleResponse)
-	err := c.cc.Invoke(ctx, "/web_log.WebLogService/UpdateSpecifiedArticle", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *RenderArticleResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
 	}
-	return out, nil
+	return ""
 }
 
-func (c *webLogServiceClient) RemoveSpecifiedArticle(ctx context.Context, in *RemoveSpecifiedArticleRequest, opts ...grpc.CallOption) (*RemoveSpecifiedArticleResponse, error) {
-	out := new(RemoveSpecifiedArticleResponse)
-	err := c.cc.Invoke(ctx, "/web_log.WebLogService/RemoveSpecifiedArticle", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *RenderArticleResponse) GetToc() []*TocEntry {
+	if x != nil {
+		return x.Toc
 	}
-	return out, nil
+	return nil
 }
 
-// WebLogServiceServer is the server API for WebLogService service.
-type WebLogServiceServer interface {
-	// Client Streaming
-	SaveAllArticles(WebLogService_SaveAllArticlesServer) error
-	// Unary
-	GetAllArticles(context.Context, *GetAllArticlesRequest) (*GetAllArticlesResponse, error)
-	// Unary
-	GetSpecifiedArticle(context.Context, *GetSpecifiedArticleRequest) (*GetSpecifiedArticleResponse, error)
-	// Unary
-	UpdateSpecifiedArticle(context.Context, *UpdateSpecifiedArticleRequest) (*UpdateSpecifiedArticleResponse, error)
-	// Unary
-	RemoveSpecifiedArticle(context.Context, *RemoveSpecifiedArticleRequest) (*RemoveSpecifiedArticleResponse, error)
+func (x *RenderArticleResponse) GetExcerpt() string {
+	if x != nil {
+		return x.Excerpt
+	}
+	return ""
 }
 
-// UnimplementedWebLogServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedWebLogServiceServer struct {
+func (x *RenderArticleResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (*UnimplementedWebLogServiceServer) SaveAllArticles(srv WebLogService_SaveAllArticlesServer) error {
-	return status.Errorf(codes.Unimplemented, "method SaveAllArticles not implemented")
+type AddTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Tags      []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 }
-func (*UnimplementedWebLogServiceServer) GetAllArticles(ctx context.Context, req *GetAllArticlesRequest) (*GetAllArticlesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllArticles not implemented")
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (*UnimplementedWebLogServiceServer) GetSpecifiedArticle(ctx context.Context, req *GetSpecifiedArticleRequest) (*GetSpecifiedArticleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSpecifiedArticle not implemented")
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (*UnimplementedWebLogServiceServer) UpdateSpecifiedArticle(ctx context.Context, req *UpdateSpecifiedArticleRequest) (*UpdateSpecifiedArticleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateSpecifiedArticle not implemented")
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (*UnimplementedWebLogServiceServer) RemoveSpecifiedArticle(ctx context.Context, req *RemoveSpecifiedArticleRequest) (*RemoveSpecifiedArticleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveSpecifiedArticle not implemented")
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{13}
 }
 
-func RegisterWebLogServiceServer(s *grpc.Server, srv WebLogServiceServer) {
-	s.RegisterService(&_WebLogService_serviceDesc, srv)
+func (x *AddTagsRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
 }
 
-func _WebLogService_SaveAllArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(WebLogServiceServer).SaveAllArticles(&webLogServiceSaveAllArticlesServer{stream})
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
 }
 
-type WebLogService_SaveAllArticlesServer interface {
-	SendAndClose(*SaveAllArticlesResponse) error
-	Recv() (*SaveAllArticlesRequest, error)
-	grpc.ServerStream
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string   `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Tags      []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
-type webLogServiceSaveAllArticlesServer struct {
-	grpc.ServerStream
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *webLogServiceSaveAllArticlesServer) SendAndClose(m *SaveAllArticlesResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *webLogServiceSaveAllArticlesServer) Recv() (*SaveAllArticlesRequest, error) {
-	m := new(SaveAllArticlesRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return m, nil
+	return mi.MessageOf(x)
 }
 
-func _WebLogService_GetAllArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllArticlesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(WebLogServiceServer).GetAllArticles(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/web_log.WebLogService/GetAllArticles",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WebLogServiceServer).GetAllArticles(ctx, req.(*GetAllArticlesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{14}
 }
 
-func _WebLogService_GetSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSpecifiedArticleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *RemoveTagsRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
-	if interceptor == nil {
-		return srv.(WebLogServiceServer).GetSpecifiedArticle(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/web_log.WebLogService/GetSpecifiedArticle",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WebLogServiceServer).GetSpecifiedArticle(ctx, req.(*GetSpecifiedArticleRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _WebLogService_UpdateSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateSpecifiedArticleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
 	}
-	if interceptor == nil {
-		return srv.(WebLogServiceServer).UpdateSpecifiedArticle(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/web_log.WebLogService/UpdateSpecifiedArticle",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WebLogServiceServer).UpdateSpecifiedArticle(ctx, req.(*UpdateSpecifiedArticleRequest))
+	return nil
+}
+
+type TagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// the tags of the article after the change
+	Tags    []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Version int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *TagsResponse) Reset() {
+	*x = TagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return interceptor(ctx, in, info, handler)
 }
 
-func _WebLogService_RemoveSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveSpecifiedArticleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *TagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagsResponse) ProtoMessage() {}
+
+func (x *TagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if interceptor == nil {
-		return srv.(WebLogServiceServer).RemoveSpecifiedArticle(ctx, in)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagsResponse.ProtoReflect.Descriptor instead.
+func (*TagsResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TagsResponse) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/web_log.WebLogService/RemoveSpecifiedArticle",
+	return ""
+}
+
+func (x *TagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WebLogServiceServer).RemoveSpecifiedArticle(ctx, req.(*RemoveSpecifiedArticleRequest))
+	return nil
+}
+
+func (x *TagsResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _WebLogService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "web_log.WebLogService",
-	HandlerType: (*WebLogServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetAllArticles",
-			Handler:    _WebLogService_GetAllArticles_Handler,
-		},
-		{
-			MethodName: "GetSpecifiedArticle",
-			Handler:    _WebLogService_GetSpecifiedArticle_Handler,
-		},
-		{
-			MethodName: "UpdateSpecifiedArticle",
-			Handler:    _WebLogService_UpdateSpecifiedArticle_Handler,
-		},
-		{
-			MethodName: "RemoveSpecifiedArticle",
-			Handler:    _WebLogService_RemoveSpecifiedArticle_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "SaveAllArticles",
-			Handler:       _WebLogService_SaveAllArticles_Handler,
-			ClientStreams: true,
+type ListTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{16}
+}
+
+type TagCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// articles with the tag or category
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TagCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TagCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// by name
+	Tags       []*TagCount `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Categories []*TagCount `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTagsResponse) GetCategories() []*TagCount {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type ListArticlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only the articles with this tag, ignoring case
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// only the articles of this category, ignoring case
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	// 20 when 0, at most 100
+	PageSize int32 `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// nextPageToken of the previous page, empty for the first page
+	PageToken string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListArticlesRequest) Reset() {
+	*x = ListArticlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesRequest) ProtoMessage() {}
+
+func (x *ListArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesRequest.ProtoReflect.Descriptor instead.
+func (*ListArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListArticlesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListArticlesRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListArticlesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListArticlesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ArticleSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string        `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Title     string        `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Tags      []string      `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category  string        `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Version   int64         `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Status    ArticleStatus `protobuf:"varint,6,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	PublishAt string        `protobuf:"bytes,7,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+}
+
+func (x *ArticleSummary) Reset() {
+	*x = ArticleSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArticleSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleSummary) ProtoMessage() {}
+
+func (x *ArticleSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleSummary.ProtoReflect.Descriptor instead.
+func (*ArticleSummary) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ArticleSummary) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *ArticleSummary) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ArticleSummary) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ArticleSummary) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ArticleSummary) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ArticleSummary) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
+}
+
+func (x *ArticleSummary) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+type Article struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID     string        `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Title         string        `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string        `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ContentFormat string        `protobuf:"bytes,4,opt,name=contentFormat,proto3" json:"contentFormat,omitempty"`
+	Version       int64         `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Tags          []string      `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category      string        `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Status        ArticleStatus `protobuf:"varint,8,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	PublishAt     string        `protobuf:"bytes,9,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	// RFC 3339 times
+	Created string `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
+	Updated string `protobuf:"bytes,11,opt,name=updated,proto3" json:"updated,omitempty"`
+}
+
+func (x *Article) Reset() {
+	*x = Article{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Article) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Article) ProtoMessage() {}
+
+func (x *Article) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Article.ProtoReflect.Descriptor instead.
+func (*Article) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Article) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *Article) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Article) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Article) GetContentFormat() string {
+	if x != nil {
+		return x.ContentFormat
+	}
+	return ""
+}
+
+func (x *Article) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Article) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Article) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Article) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
+}
+
+func (x *Article) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *Article) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Article) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+type WatchArticlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only the events of articles with one of these tags, ignoring case
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// only the events of these articles
+	ArticleIDs []string `protobuf:"bytes,2,rep,name=articleIDs,proto3" json:"articleIDs,omitempty"`
+	// send the article with created and updated events
+	IncludeArticle bool `protobuf:"varint,3,opt,name=includeArticle,proto3" json:"includeArticle,omitempty"`
+	// cursor of the last event received, the events after it are sent first; empty for only new events
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *WatchArticlesRequest) Reset() {
+	*x = WatchArticlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchArticlesRequest) ProtoMessage() {}
+
+func (x *WatchArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchArticlesRequest.ProtoReflect.Descriptor instead.
+func (*WatchArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchArticlesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WatchArticlesRequest) GetArticleIDs() []string {
+	if x != nil {
+		return x.ArticleIDs
+	}
+	return nil
+}
+
+func (x *WatchArticlesRequest) GetIncludeArticle() bool {
+	if x != nil {
+		return x.IncludeArticle
+	}
+	return false
+}
+
+func (x *WatchArticlesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ArticleEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      ArticleEventType `protobuf:"varint,1,opt,name=type,proto3,enum=web_log.ArticleEventType" json:"type,omitempty"`
+	ArticleID string           `protobuf:"bytes,2,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// version of the article after the change, the last version for a deleted article
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// set with includeArticle, except for deleted events
+	Article *Article `protobuf:"bytes,4,opt,name=article,proto3" json:"article,omitempty"`
+	// pass as WatchArticlesRequest.cursor to resume after this event
+	Cursor string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// RFC 3339 time of the change, or of the heartbeat
+	Time string `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *ArticleEvent) Reset() {
+	*x = ArticleEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArticleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleEvent) ProtoMessage() {}
+
+func (x *ArticleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleEvent.ProtoReflect.Descriptor instead.
+func (*ArticleEvent) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ArticleEvent) GetType() ArticleEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ArticleEventType_ARTICLE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ArticleEvent) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *ArticleEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ArticleEvent) GetArticle() *Article {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+func (x *ArticleEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ArticleEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+type SetArticleStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// unchanged when unspecified
+	Status ArticleStatus `protobuf:"varint,2,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	// RFC 3339, unchanged when not set, unscheduled when set to ""
+	PublishAt *string `protobuf:"bytes,3,opt,name=publishAt,proto3,oneof" json:"publishAt,omitempty"`
+}
+
+func (x *SetArticleStatusRequest) Reset() {
+	*x = SetArticleStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetArticleStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetArticleStatusRequest) ProtoMessage() {}
+
+func (x *SetArticleStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetArticleStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetArticleStatusRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetArticleStatusRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *SetArticleStatusRequest) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
+}
+
+func (x *SetArticleStatusRequest) GetPublishAt() string {
+	if x != nil && x.PublishAt != nil {
+		return *x.PublishAt
+	}
+	return ""
+}
+
+type SetArticleStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string        `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	Status    ArticleStatus `protobuf:"varint,2,opt,name=status,proto3,enum=web_log.ArticleStatus" json:"status,omitempty"`
+	PublishAt string        `protobuf:"bytes,3,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	Version   int64         `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *SetArticleStatusResponse) Reset() {
+	*x = SetArticleStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetArticleStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetArticleStatusResponse) ProtoMessage() {}
+
+func (x *SetArticleStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetArticleStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetArticleStatusResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetArticleStatusResponse) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *SetArticleStatusResponse) GetStatus() ArticleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArticleStatus_ARTICLE_STATUS_UNSPECIFIED
+}
+
+func (x *SetArticleStatusResponse) GetPublishAt() string {
+	if x != nil {
+		return x.PublishAt
+	}
+	return ""
+}
+
+func (x *SetArticleStatusResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListArticlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Articles []*ArticleSummary `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// articles matching the filter
+	TotalSize int32 `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+}
+
+func (x *ListArticlesResponse) Reset() {
+	*x = ListArticlesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesResponse) ProtoMessage() {}
+
+func (x *ListArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesResponse.ProtoReflect.Descriptor instead.
+func (*ListArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListArticlesResponse) GetArticles() []*ArticleSummary {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+func (x *ListArticlesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListArticlesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type Comment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID string `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	ArticleID string `protobuf:"bytes,2,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// commentID of the comment replied to, empty for a top-level comment
+	ParentID string `protobuf:"bytes,3,opt,name=parentID,proto3" json:"parentID,omitempty"`
+	// principal who wrote the comment
+	Author  string           `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Content string           `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Status  ModerationStatus `protobuf:"varint,6,opt,name=status,proto3,enum=web_log.ModerationStatus" json:"status,omitempty"`
+	// RFC 3339 times
+	Created string `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	Updated string `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	// replies by creation time, filled by ListComments
+	Replies []*Comment `protobuf:"bytes,9,rep,name=replies,proto3" json:"replies,omitempty"`
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Comment) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+func (x *Comment) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *Comment) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetStatus() ModerationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ModerationStatus_PENDING
+}
+
+func (x *Comment) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Comment) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+func (x *Comment) GetReplies() []*Comment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// empty for a top-level comment
+	ParentID string `protobuf:"bytes,2,opt,name=parentID,proto3" json:"parentID,omitempty"`
+	Content  string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddCommentRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetParentID() string {
+	if x != nil {
+		return x.ParentID
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArticleID string `protobuf:"bytes,1,opt,name=articleID,proto3" json:"articleID,omitempty"`
+	// top-level comments per page, 20 when 0, at most 100
+	PageSize int32 `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// nextPageToken of the previous page, empty for the first page
+	PageToken string `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListCommentsRequest) GetArticleID() string {
+	if x != nil {
+		return x.ArticleID
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// top-level comments by creation time, with their replies
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	// top-level comments visible to the caller
+	TotalSize int32 `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListCommentsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type EditCommentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID string `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	Content   string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *EditCommentRequest) Reset() {
+	*x = EditCommentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditCommentRequest) ProtoMessage() {}
+
+func (x *EditCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditCommentRequest.ProtoReflect.Descriptor instead.
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EditCommentRequest) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+func (x *EditCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ModerateCommentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID string           `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	Status    ModerationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=web_log.ModerationStatus" json:"status,omitempty"`
+}
+
+func (x *ModerateCommentRequest) Reset() {
+	*x = ModerateCommentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModerateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateCommentRequest) ProtoMessage() {}
+
+func (x *ModerateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateCommentRequest.ProtoReflect.Descriptor instead.
+func (*ModerateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ModerateCommentRequest) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+func (x *ModerateCommentRequest) GetStatus() ModerationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ModerationStatus_PENDING
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID string `protobuf:"bytes,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteCommentRequest) GetCommentID() string {
+	if x != nil {
+		return x.CommentID
+	}
+	return ""
+}
+
+type DeleteCommentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the comment and its replies
+	Deleted int32 `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResponse) ProtoMessage() {}
+
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_log_web_log_pb_web_log_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return file_web_log_web_log_pb_web_log_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteCommentResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+var File_web_log_web_log_pb_web_log_proto protoreflect.FileDescriptor
+
+var file_web_log_web_log_pb_web_log_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2f, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f,
+	0x67, 0x5f, 0x70, 0x62, 0x2f, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x07, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x1a, 0x1c, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf8, 0x01, 0x0a, 0x16, 0x53, 0x61,
+	0x76, 0x65, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x24,
+	0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54,
+	0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x41, 0x74, 0x22, 0x31, 0x0a, 0x17, 0x53, 0x61, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x30, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x22, 0x3a, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x22, 0xa9,
+	0x02, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77,
+	0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x22, 0xf8, 0x01, 0x0a, 0x1d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0d, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x1f, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x03, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x88, 0x01, 0x01,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x38, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x3d, 0x0a, 0x1d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x38,
+	0x0a, 0x1e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x34, 0x0a, 0x14, 0x52, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x44,
+	0x0a, 0x08, 0x54, 0x6f, 0x63, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x22, 0xb8, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x74, 0x6d, 0x6c, 0x12, 0x23, 0x0a, 0x03, 0x74, 0x6f, 0x63, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x54, 0x6f,
+	0x63, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x74, 0x6f, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x78, 0x63, 0x65, 0x72, 0x70, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78,
+	0x63, 0x65, 0x72, 0x70, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x42, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x22, 0x45, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x5a, 0x0a, 0x0c, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a, 0x08, 0x54, 0x61, 0x67,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x6c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x22, 0x7d, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xdc, 0x01, 0x0a,
+	0x0e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x22, 0xc9, 0x02, 0x0a, 0x07,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49,
+	0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x65, 0x49, 0x44, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x22, 0xcd, 0x01, 0x0a, 0x0c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x07,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52,
+	0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x2e,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21,
+	0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x88, 0x01,
+	0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x22,
+	0xa0, 0x01, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62,
+	0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0xa6, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x77, 0x65, 0x62,
+	0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x22, 0x67, 0x0a,
+	0x11, 0x41, 0x64, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x6d, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65,
+	0x22, 0x4c, 0x0a, 0x12, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x69,
+	0x0a, 0x16, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x34, 0x0a, 0x14, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22,
+	0x31, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x2a, 0x66, 0x0a, 0x0d, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x52, 0x54, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x52, 0x41, 0x46, 0x54, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x49, 0x4e, 0x5f, 0x52, 0x45, 0x56, 0x49, 0x45, 0x57, 0x10, 0x02, 0x12, 0x0d, 0x0a,
+	0x09, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
+	0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x6c, 0x0a, 0x10, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22,
+	0x0a, 0x1e, 0x41, 0x52, 0x54, 0x49, 0x43, 0x4c, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
+	0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x45, 0x41,
+	0x52, 0x54, 0x42, 0x45, 0x41, 0x54, 0x10, 0x04, 0x2a, 0x37, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07,
+	0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x50, 0x50,
+	0x52, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x50, 0x41, 0x4d, 0x10,
+	0x02, 0x32, 0xa4, 0x0b, 0x0a, 0x0d, 0x57, 0x65, 0x62, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x6f, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x53, 0x61, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f,
+	0x67, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x28, 0x01, 0x12, 0x67, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12,
+	0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x82, 0x01,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x77, 0x65, 0x62,
+	0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49,
+	0x44, 0x7d, 0x12, 0x8e, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x26, 0x2e,
+	0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70,
+	0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x32, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x49, 0x44, 0x7d, 0x12, 0x8b, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x70,
+	0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x26,
+	0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x2a, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44,
+	0x7d, 0x12, 0x75, 0x0a, 0x0d, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x52, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x52, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x49, 0x44, 0x7d, 0x2f, 0x68, 0x74, 0x6d, 0x6c, 0x12, 0x63, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x64,
+	0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x77,
+	0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x3a, 0x01, 0x2a, 0x22, 0x1d,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x7d, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x66, 0x0a,
+	0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x2e, 0x77, 0x65,
+	0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f,
+	0x67, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x2a, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x7d,
+	0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x51, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x18, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x65,
+	0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08,
+	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x92, 0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x5f,
+	0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f,
+	0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x45, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x3f, 0x5a, 0x24,
+	0x12, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x2f, 0x7b, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x7d, 0x2f, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b,
+	0x74, 0x61, 0x67, 0x7d, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x83, 0x01,
+	0x0a, 0x10, 0x53, 0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x20, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x53, 0x65, 0x74,
+	0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x53,
+	0x65, 0x74, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a,
+	0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x7d, 0x2f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x63, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x3a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x32, 0xbb, 0x04, 0x0a, 0x0e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x68, 0x0a, 0x0a, 0x41,
+	0x64, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x77, 0x65, 0x62, 0x5f,
+	0x6c, 0x6f, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x26, 0x3a,
+	0x01, 0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x44, 0x7d, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x76, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x29, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x23, 0x12, 0x21, 0x2f, 0x76, 0x31, 0x2f,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x65, 0x49, 0x44, 0x7d, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x61, 0x0a,
+	0x0b, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x77,
+	0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x77, 0x65, 0x62, 0x5f,
+	0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x23, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x32, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x7d,
+	0x12, 0x72, 0x0a, 0x0f, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x4d, 0x6f,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x26, 0x3a, 0x01,
+	0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x7b, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x7d, 0x3a, 0x6d, 0x6f, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x12, 0x70, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x2a, 0x18, 0x2f, 0x76,
+	0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x7d, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x77,
+	0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2f, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x2f, 0x77,
+	0x65, 0x62, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x62, 0x3b, 0x77, 0x65, 0x62, 0x5f, 0x6c, 0x6f,
+	0x67, 0x5f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_web_log_web_log_pb_web_log_proto_rawDescOnce sync.Once
+	file_web_log_web_log_pb_web_log_proto_rawDescData = file_web_log_web_log_pb_web_log_proto_rawDesc
+)
+
+func file_web_log_web_log_pb_web_log_proto_rawDescGZIP() []byte {
+	file_web_log_web_log_pb_web_log_proto_rawDescOnce.Do(func() {
+		file_web_log_web_log_pb_web_log_proto_rawDescData = protoimpl.X.CompressGZIP(file_web_log_web_log_pb_web_log_proto_rawDescData)
+	})
+	return file_web_log_web_log_pb_web_log_proto_rawDescData
+}
+
+var file_web_log_web_log_pb_web_log_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_web_log_web_log_pb_web_log_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_web_log_web_log_pb_web_log_proto_goTypes = []any{
+	(ArticleStatus)(0),                     // 0: web_log.ArticleStatus
+	(ArticleEventType)(0),                  // 1: web_log.ArticleEventType
+	(ModerationStatus)(0),                  // 2: web_log.ModerationStatus
+	(*SaveAllArticlesRequest)(nil),         // 3: web_log.SaveAllArticlesRequest
+	(*SaveAllArticlesResponse)(nil),        // 4: web_log.SaveAllArticlesResponse
+	(*GetAllArticlesRequest)(nil),          // 5: web_log.GetAllArticlesRequest
+	(*GetAllArticlesResponse)(nil),         // 6: web_log.GetAllArticlesResponse
+	(*GetSpecifiedArticleRequest)(nil),     // 7: web_log.GetSpecifiedArticleRequest
+	(*GetSpecifiedArticleResponse)(nil),    // 8: web_log.GetSpecifiedArticleResponse
+	(*UpdateSpecifiedArticleRequest)(nil),  // 9: web_log.UpdateSpecifiedArticleRequest
+	(*UpdateSpecifiedArticleResponse)(nil), // 10: web_log.UpdateSpecifiedArticleResponse
+	(*RemoveSpecifiedArticleRequest)(nil),  // 11: web_log.RemoveSpecifiedArticleRequest
+	(*RemoveSpecifiedArticleResponse)(nil), // 12: web_log.RemoveSpecifiedArticleResponse
+	(*RenderArticleRequest)(nil),           // 13: web_log.RenderArticleRequest
+	(*TocEntry)(nil),                       // 14: web_log.TocEntry
+	(*RenderArticleResponse)(nil),          // 15: web_log.RenderArticleResponse
+	(*AddTagsRequest)(nil),                 // 16: web_log.AddTagsRequest
+	(*RemoveTagsRequest)(nil),              // 17: web_log.RemoveTagsRequest
+	(*TagsResponse)(nil),                   // 18: web_log.TagsResponse
+	(*ListTagsRequest)(nil),                // 19: web_log.ListTagsRequest
+	(*TagCount)(nil),                       // 20: web_log.TagCount
+	(*ListTagsResponse)(nil),               // 21: web_log.ListTagsResponse
+	(*ListArticlesRequest)(nil),            // 22: web_log.ListArticlesRequest
+	(*ArticleSummary)(nil),                 // 23: web_log.ArticleSummary
+	(*Article)(nil),                        // 24: web_log.Article
+	(*WatchArticlesRequest)(nil),           // 25: web_log.WatchArticlesRequest
+	(*ArticleEvent)(nil),                   // 26: web_log.ArticleEvent
+	(*SetArticleStatusRequest)(nil),        // 27: web_log.SetArticleStatusRequest
+	(*SetArticleStatusResponse)(nil),       // 28: web_log.SetArticleStatusResponse
+	(*ListArticlesResponse)(nil),           // 29: web_log.ListArticlesResponse
+	(*Comment)(nil),                        // 30: web_log.Comment
+	(*AddCommentRequest)(nil),              // 31: web_log.AddCommentRequest
+	(*ListCommentsRequest)(nil),            // 32: web_log.ListCommentsRequest
+	(*ListCommentsResponse)(nil),           // 33: web_log.ListCommentsResponse
+	(*EditCommentRequest)(nil),             // 34: web_log.EditCommentRequest
+	(*ModerateCommentRequest)(nil),         // 35: web_log.ModerateCommentRequest
+	(*DeleteCommentRequest)(nil),           // 36: web_log.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),          // 37: web_log.DeleteCommentResponse
+}
+var file_web_log_web_log_pb_web_log_proto_depIdxs = []int32{
+	0,  // 0: web_log.SaveAllArticlesRequest.status:type_name -> web_log.ArticleStatus
+	0,  // 1: web_log.GetSpecifiedArticleResponse.status:type_name -> web_log.ArticleStatus
+	14, // 2: web_log.RenderArticleResponse.toc:type_name -> web_log.TocEntry
+	20, // 3: web_log.ListTagsResponse.tags:type_name -> web_log.TagCount
+	20, // 4: web_log.ListTagsResponse.categories:type_name -> web_log.TagCount
+	0,  // 5: web_log.ArticleSummary.status:type_name -> web_log.ArticleStatus
+	0,  // 6: web_log.Article.status:type_name -> web_log.ArticleStatus
+	1,  // 7: web_log.ArticleEvent.type:type_name -> web_log.ArticleEventType
+	24, // 8: web_log.ArticleEvent.article:type_name -> web_log.Article
+	0,  // 9: web_log.SetArticleStatusRequest.status:type_name -> web_log.ArticleStatus
+	0,  // 10: web_log.SetArticleStatusResponse.status:type_name -> web_log.ArticleStatus
+	23, // 11: web_log.ListArticlesResponse.articles:type_name -> web_log.ArticleSummary
+	2,  // 12: web_log.Comment.status:type_name -> web_log.ModerationStatus
+	30, // 13: web_log.Comment.replies:type_name -> web_log.Comment
+	30, // 14: web_log.ListCommentsResponse.comments:type_name -> web_log.Comment
+	2,  // 15: web_log.ModerateCommentRequest.status:type_name -> web_log.ModerationStatus
+	3,  // 16: web_log.WebLogService.SaveAllArticles:input_type -> web_log.SaveAllArticlesRequest
+	5,  // 17: web_log.WebLogService.GetAllArticles:input_type -> web_log.GetAllArticlesRequest
+	7,  // 18: web_log.WebLogService.GetSpecifiedArticle:input_type -> web_log.GetSpecifiedArticleRequest
+	9,  // 19: web_log.WebLogService.UpdateSpecifiedArticle:input_type -> web_log.UpdateSpecifiedArticleRequest
+	11, // 20: web_log.WebLogService.RemoveSpecifiedArticle:input_type -> web_log.RemoveSpecifiedArticleRequest
+	13, // 21: web_log.WebLogService.RenderArticle:input_type -> web_log.RenderArticleRequest
+	16, // 22: web_log.WebLogService.AddTags:input_type -> web_log.AddTagsRequest
+	17, // 23: web_log.WebLogService.RemoveTags:input_type -> web_log.RemoveTagsRequest
+	19, // 24: web_log.WebLogService.ListTags:input_type -> web_log.ListTagsRequest
+	22, // 25: web_log.WebLogService.ListArticles:input_type -> web_log.ListArticlesRequest
+	27, // 26: web_log.WebLogService.SetArticleStatus:input_type -> web_log.SetArticleStatusRequest
+	25, // 27: web_log.WebLogService.WatchArticles:input_type -> web_log.WatchArticlesRequest
+	31, // 28: web_log.CommentService.AddComment:input_type -> web_log.AddCommentRequest
+	32, // 29: web_log.CommentService.ListComments:input_type -> web_log.ListCommentsRequest
+	34, // 30: web_log.CommentService.EditComment:input_type -> web_log.EditCommentRequest
+	35, // 31: web_log.CommentService.ModerateComment:input_type -> web_log.ModerateCommentRequest
+	36, // 32: web_log.CommentService.DeleteComment:input_type -> web_log.DeleteCommentRequest
+	4,  // 33: web_log.WebLogService.SaveAllArticles:output_type -> web_log.SaveAllArticlesResponse
+	6,  // 34: web_log.WebLogService.GetAllArticles:output_type -> web_log.GetAllArticlesResponse
+	8,  // 35: web_log.WebLogService.GetSpecifiedArticle:output_type -> web_log.GetSpecifiedArticleResponse
+	10, // 36: web_log.WebLogService.UpdateSpecifiedArticle:output_type -> web_log.UpdateSpecifiedArticleResponse
+	12, // 37: web_log.WebLogService.RemoveSpecifiedArticle:output_type -> web_log.RemoveSpecifiedArticleResponse
+	15, // 38: web_log.WebLogService.RenderArticle:output_type -> web_log.RenderArticleResponse
+	18, // 39: web_log.WebLogService.AddTags:output_type -> web_log.TagsResponse
+	18, // 40: web_log.WebLogService.RemoveTags:output_type -> web_log.TagsResponse
+	21, // 41: web_log.WebLogService.ListTags:output_type -> web_log.ListTagsResponse
+	29, // 42: web_log.WebLogService.ListArticles:output_type -> web_log.ListArticlesResponse
+	28, // 43: web_log.WebLogService.SetArticleStatus:output_type -> web_log.SetArticleStatusResponse
+	26, // 44: web_log.WebLogService.WatchArticles:output_type -> web_log.ArticleEvent
+	30, // 45: web_log.CommentService.AddComment:output_type -> web_log.Comment
+	33, // 46: web_log.CommentService.ListComments:output_type -> web_log.ListCommentsResponse
+	30, // 47: web_log.CommentService.EditComment:output_type -> web_log.Comment
+	30, // 48: web_log.CommentService.ModerateComment:output_type -> web_log.Comment
+	37, // 49: web_log.CommentService.DeleteComment:output_type -> web_log.DeleteCommentResponse
+	33, // [33:50] is the sub-list for method output_type
+	16, // [16:33] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_web_log_web_log_pb_web_log_proto_init() }
+func file_web_log_web_log_pb_web_log_proto_init() {
+	if File_web_log_web_log_pb_web_log_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_web_log_web_log_pb_web_log_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SaveAllArticlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SaveAllArticlesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAllArticlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAllArticlesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSpecifiedArticleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSpecifiedArticleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSpecifiedArticleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSpecifiedArticleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveSpecifiedArticleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveSpecifiedArticleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*RenderArticleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*TocEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*RenderArticleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*AddTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*TagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*TagCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*ListArticlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*ArticleSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Article); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchArticlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*ArticleEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*SetArticleStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*SetArticleStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*ListArticlesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*Comment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*AddCommentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCommentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCommentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*EditCommentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*ModerateCommentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteCommentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_web_log_web_log_pb_web_log_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteCommentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_web_log_web_log_pb_web_log_proto_msgTypes[6].OneofWrappers = []any{}
+	file_web_log_web_log_pb_web_log_proto_msgTypes[24].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_web_log_web_log_pb_web_log_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   2,
 		},
-	},
-	Metadata: "web_log/web_log_pb/web_log.proto",
+		GoTypes:           file_web_log_web_log_pb_web_log_proto_goTypes,
+		DependencyIndexes: file_web_log_web_log_pb_web_log_proto_depIdxs,
+		EnumInfos:         file_web_log_web_log_pb_web_log_proto_enumTypes,
+		MessageInfos:      file_web_log_web_log_pb_web_log_proto_msgTypes,
+	}.Build()
+	File_web_log_web_log_pb_web_log_proto = out.File
+	file_web_log_web_log_pb_web_log_proto_rawDesc = nil
+	file_web_log_web_log_pb_web_log_proto_goTypes = nil
+	file_web_log_web_log_pb_web_log_proto_depIdxs = nil
 }
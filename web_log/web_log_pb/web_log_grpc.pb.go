@@ -0,0 +1,832 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.6.1
+// source: web_log/web_log_pb/web_log.proto
+
+package web_log_pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WebLogService_SaveAllArticles_FullMethodName        = "/web_log.WebLogService/SaveAllArticles"
+	WebLogService_GetAllArticles_FullMethodName         = "/web_log.WebLogService/GetAllArticles"
+	WebLogService_GetSpecifiedArticle_FullMethodName    = "/web_log.WebLogService/GetSpecifiedArticle"
+	WebLogService_UpdateSpecifiedArticle_FullMethodName = "/web_log.WebLogService/UpdateSpecifiedArticle"
+	WebLogService_RemoveSpecifiedArticle_FullMethodName = "/web_log.WebLogService/RemoveSpecifiedArticle"
+	WebLogService_RenderArticle_FullMethodName          = "/web_log.WebLogService/RenderArticle"
+	WebLogService_AddTags_FullMethodName                = "/web_log.WebLogService/AddTags"
+	WebLogService_RemoveTags_FullMethodName             = "/web_log.WebLogService/RemoveTags"
+	WebLogService_ListTags_FullMethodName               = "/web_log.WebLogService/ListTags"
+	WebLogService_ListArticles_FullMethodName           = "/web_log.WebLogService/ListArticles"
+	WebLogService_SetArticleStatus_FullMethodName       = "/web_log.WebLogService/SetArticleStatus"
+	WebLogService_WatchArticles_FullMethodName          = "/web_log.WebLogService/WatchArticles"
+)
+
+// WebLogServiceClient is the client API for WebLogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebLogServiceClient interface {
+	// Client Streaming
+	// POST /v1/articles takes newline-delimited {"article": "title\ncontent"} objects
+	SaveAllArticles(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SaveAllArticlesRequest, SaveAllArticlesResponse], error)
+	// Unary
+	GetAllArticles(ctx context.Context, in *GetAllArticlesRequest, opts ...grpc.CallOption) (*GetAllArticlesResponse, error)
+	// Unary
+	GetSpecifiedArticle(ctx context.Context, in *GetSpecifiedArticleRequest, opts ...grpc.CallOption) (*GetSpecifiedArticleResponse, error)
+	// Unary
+	UpdateSpecifiedArticle(ctx context.Context, in *UpdateSpecifiedArticleRequest, opts ...grpc.CallOption) (*UpdateSpecifiedArticleResponse, error)
+	// Unary
+	RemoveSpecifiedArticle(ctx context.Context, in *RemoveSpecifiedArticleRequest, opts ...grpc.CallOption) (*RemoveSpecifiedArticleResponse, error)
+	// Unary
+	RenderArticle(ctx context.Context, in *RenderArticleRequest, opts ...grpc.CallOption) (*RenderArticleResponse, error)
+	// Unary
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	// Unary
+	// DELETE /v1/articles/{articleID}/tags?tags=a&tags=b
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	// Unary
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Unary
+	ListArticles(ctx context.Context, in *ListArticlesRequest, opts ...grpc.CallOption) (*ListArticlesResponse, error)
+	// Unary
+	SetArticleStatus(ctx context.Context, in *SetArticleStatusRequest, opts ...grpc.CallOption) (*SetArticleStatusResponse, error)
+	// Server Streaming
+	// GET /v1/articles:watch sends newline-delimited {"result": {...}} events until the client disconnects
+	WatchArticles(ctx context.Context, in *WatchArticlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArticleEvent], error)
+}
+
+type webLogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebLogServiceClient(cc grpc.ClientConnInterface) WebLogServiceClient {
+	return &webLogServiceClient{cc}
+}
+
+func (c *webLogServiceClient) SaveAllArticles(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SaveAllArticlesRequest, SaveAllArticlesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WebLogService_ServiceDesc.Streams[0], WebLogService_SaveAllArticles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SaveAllArticlesRequest, SaveAllArticlesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebLogService_SaveAllArticlesClient = grpc.ClientStreamingClient[SaveAllArticlesRequest, SaveAllArticlesResponse]
+
+func (c *webLogServiceClient) GetAllArticles(ctx context.Context, in *GetAllArticlesRequest, opts ...grpc.CallOption) (*GetAllArticlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllArticlesResponse)
+	err := c.cc.Invoke(ctx, WebLogService_GetAllArticles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) GetSpecifiedArticle(ctx context.Context, in *GetSpecifiedArticleRequest, opts ...grpc.CallOption) (*GetSpecifiedArticleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSpecifiedArticleResponse)
+	err := c.cc.Invoke(ctx, WebLogService_GetSpecifiedArticle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) UpdateSpecifiedArticle(ctx context.Context, in *UpdateSpecifiedArticleRequest, opts ...grpc.CallOption) (*UpdateSpecifiedArticleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSpecifiedArticleResponse)
+	err := c.cc.Invoke(ctx, WebLogService_UpdateSpecifiedArticle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) RemoveSpecifiedArticle(ctx context.Context, in *RemoveSpecifiedArticleRequest, opts ...grpc.CallOption) (*RemoveSpecifiedArticleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSpecifiedArticleResponse)
+	err := c.cc.Invoke(ctx, WebLogService_RemoveSpecifiedArticle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) RenderArticle(ctx context.Context, in *RenderArticleRequest, opts ...grpc.CallOption) (*RenderArticleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderArticleResponse)
+	err := c.cc.Invoke(ctx, WebLogService_RenderArticle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, WebLogService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, WebLogService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, WebLogService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) ListArticles(ctx context.Context, in *ListArticlesRequest, opts ...grpc.CallOption) (*ListArticlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArticlesResponse)
+	err := c.cc.Invoke(ctx, WebLogService_ListArticles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) SetArticleStatus(ctx context.Context, in *SetArticleStatusRequest, opts ...grpc.CallOption) (*SetArticleStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetArticleStatusResponse)
+	err := c.cc.Invoke(ctx, WebLogService_SetArticleStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webLogServiceClient) WatchArticles(ctx context.Context, in *WatchArticlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArticleEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WebLogService_ServiceDesc.Streams[1], WebLogService_WatchArticles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchArticlesRequest, ArticleEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebLogService_WatchArticlesClient = grpc.ServerStreamingClient[ArticleEvent]
+
+// WebLogServiceServer is the server API for WebLogService service.
+// All implementations must embed UnimplementedWebLogServiceServer
+// for forward compatibility.
+type WebLogServiceServer interface {
+	// Client Streaming
+	// POST /v1/articles takes newline-delimited {"article": "title\ncontent"} objects
+	SaveAllArticles(grpc.ClientStreamingServer[SaveAllArticlesRequest, SaveAllArticlesResponse]) error
+	// Unary
+	GetAllArticles(context.Context, *GetAllArticlesRequest) (*GetAllArticlesResponse, error)
+	// Unary
+	GetSpecifiedArticle(context.Context, *GetSpecifiedArticleRequest) (*GetSpecifiedArticleResponse, error)
+	// Unary
+	UpdateSpecifiedArticle(context.Context, *UpdateSpecifiedArticleRequest) (*UpdateSpecifiedArticleResponse, error)
+	// Unary
+	RemoveSpecifiedArticle(context.Context, *RemoveSpecifiedArticleRequest) (*RemoveSpecifiedArticleResponse, error)
+	// Unary
+	RenderArticle(context.Context, *RenderArticleRequest) (*RenderArticleResponse, error)
+	// Unary
+	AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error)
+	// Unary
+	// DELETE /v1/articles/{articleID}/tags?tags=a&tags=b
+	RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error)
+	// Unary
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Unary
+	ListArticles(context.Context, *ListArticlesRequest) (*ListArticlesResponse, error)
+	// Unary
+	SetArticleStatus(context.Context, *SetArticleStatusRequest) (*SetArticleStatusResponse, error)
+	// Server Streaming
+	// GET /v1/articles:watch sends newline-delimited {"result": {...}} events until the client disconnects
+	WatchArticles(*WatchArticlesRequest, grpc.ServerStreamingServer[ArticleEvent]) error
+	mustEmbedUnimplementedWebLogServiceServer()
+}
+
+// UnimplementedWebLogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebLogServiceServer struct{}
+
+func (UnimplementedWebLogServiceServer) SaveAllArticles(grpc.ClientStreamingServer[SaveAllArticlesRequest, SaveAllArticlesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SaveAllArticles not implemented")
+}
+func (UnimplementedWebLogServiceServer) GetAllArticles(context.Context, *GetAllArticlesRequest) (*GetAllArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllArticles not implemented")
+}
+func (UnimplementedWebLogServiceServer) GetSpecifiedArticle(context.Context, *GetSpecifiedArticleRequest) (*GetSpecifiedArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpecifiedArticle not implemented")
+}
+func (UnimplementedWebLogServiceServer) UpdateSpecifiedArticle(context.Context, *UpdateSpecifiedArticleRequest) (*UpdateSpecifiedArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSpecifiedArticle not implemented")
+}
+func (UnimplementedWebLogServiceServer) RemoveSpecifiedArticle(context.Context, *RemoveSpecifiedArticleRequest) (*RemoveSpecifiedArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSpecifiedArticle not implemented")
+}
+func (UnimplementedWebLogServiceServer) RenderArticle(context.Context, *RenderArticleRequest) (*RenderArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderArticle not implemented")
+}
+func (UnimplementedWebLogServiceServer) AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedWebLogServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedWebLogServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedWebLogServiceServer) ListArticles(context.Context, *ListArticlesRequest) (*ListArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArticles not implemented")
+}
+func (UnimplementedWebLogServiceServer) SetArticleStatus(context.Context, *SetArticleStatusRequest) (*SetArticleStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetArticleStatus not implemented")
+}
+func (UnimplementedWebLogServiceServer) WatchArticles(*WatchArticlesRequest, grpc.ServerStreamingServer[ArticleEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchArticles not implemented")
+}
+func (UnimplementedWebLogServiceServer) mustEmbedUnimplementedWebLogServiceServer() {}
+func (UnimplementedWebLogServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeWebLogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebLogServiceServer will
+// result in compilation errors.
+type UnsafeWebLogServiceServer interface {
+	mustEmbedUnimplementedWebLogServiceServer()
+}
+
+func RegisterWebLogServiceServer(s grpc.ServiceRegistrar, srv WebLogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebLogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebLogService_ServiceDesc, srv)
+}
+
+func _WebLogService_SaveAllArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WebLogServiceServer).SaveAllArticles(&grpc.GenericServerStream[SaveAllArticlesRequest, SaveAllArticlesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebLogService_SaveAllArticlesServer = grpc.ClientStreamingServer[SaveAllArticlesRequest, SaveAllArticlesResponse]
+
+func _WebLogService_GetAllArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).GetAllArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_GetAllArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).GetAllArticles(ctx, req.(*GetAllArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_GetSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpecifiedArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).GetSpecifiedArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_GetSpecifiedArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).GetSpecifiedArticle(ctx, req.(*GetSpecifiedArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_UpdateSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSpecifiedArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).UpdateSpecifiedArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_UpdateSpecifiedArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).UpdateSpecifiedArticle(ctx, req.(*UpdateSpecifiedArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_RemoveSpecifiedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSpecifiedArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).RemoveSpecifiedArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_RemoveSpecifiedArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).RemoveSpecifiedArticle(ctx, req.(*RemoveSpecifiedArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_RenderArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).RenderArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_RenderArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).RenderArticle(ctx, req.(*RenderArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_ListArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).ListArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_ListArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).ListArticles(ctx, req.(*ListArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_SetArticleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetArticleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebLogServiceServer).SetArticleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebLogService_SetArticleStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebLogServiceServer).SetArticleStatus(ctx, req.(*SetArticleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebLogService_WatchArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchArticlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebLogServiceServer).WatchArticles(m, &grpc.GenericServerStream[WatchArticlesRequest, ArticleEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WebLogService_WatchArticlesServer = grpc.ServerStreamingServer[ArticleEvent]
+
+// WebLogService_ServiceDesc is the grpc.ServiceDesc for WebLogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebLogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "web_log.WebLogService",
+	HandlerType: (*WebLogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAllArticles",
+			Handler:    _WebLogService_GetAllArticles_Handler,
+		},
+		{
+			MethodName: "GetSpecifiedArticle",
+			Handler:    _WebLogService_GetSpecifiedArticle_Handler,
+		},
+		{
+			MethodName: "UpdateSpecifiedArticle",
+			Handler:    _WebLogService_UpdateSpecifiedArticle_Handler,
+		},
+		{
+			MethodName: "RemoveSpecifiedArticle",
+			Handler:    _WebLogService_RemoveSpecifiedArticle_Handler,
+		},
+		{
+			MethodName: "RenderArticle",
+			Handler:    _WebLogService_RenderArticle_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _WebLogService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _WebLogService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _WebLogService_ListTags_Handler,
+		},
+		{
+			MethodName: "ListArticles",
+			Handler:    _WebLogService_ListArticles_Handler,
+		},
+		{
+			MethodName: "SetArticleStatus",
+			Handler:    _WebLogService_SetArticleStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SaveAllArticles",
+			Handler:       _WebLogService_SaveAllArticles_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchArticles",
+			Handler:       _WebLogService_WatchArticles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "web_log/web_log_pb/web_log.proto",
+}
+
+const (
+	CommentService_AddComment_FullMethodName      = "/web_log.CommentService/AddComment"
+	CommentService_ListComments_FullMethodName    = "/web_log.CommentService/ListComments"
+	CommentService_EditComment_FullMethodName     = "/web_log.CommentService/EditComment"
+	CommentService_ModerateComment_FullMethodName = "/web_log.CommentService/ModerateComment"
+	CommentService_DeleteComment_FullMethodName   = "/web_log.CommentService/DeleteComment"
+)
+
+// CommentServiceClient is the client API for CommentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CommentServiceClient interface {
+	// Unary
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// Unary
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Unary
+	EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// Unary
+	ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// Unary
+	// deletes the replies too
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+}
+
+type commentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommentServiceClient(cc grpc.ClientConnInterface) CommentServiceClient {
+	return &commentServiceClient{cc}
+}
+
+func (c *commentServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, CommentService_AddComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, CommentService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, CommentService_EditComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ModerateComment(ctx context.Context, in *ModerateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, CommentService_ModerateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommentServiceServer is the server API for CommentService service.
+// All implementations must embed UnimplementedCommentServiceServer
+// for forward compatibility.
+type CommentServiceServer interface {
+	// Unary
+	AddComment(context.Context, *AddCommentRequest) (*Comment, error)
+	// Unary
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Unary
+	EditComment(context.Context, *EditCommentRequest) (*Comment, error)
+	// Unary
+	ModerateComment(context.Context, *ModerateCommentRequest) (*Comment, error)
+	// Unary
+	// deletes the replies too
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+// UnimplementedCommentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCommentServiceServer struct{}
+
+func (UnimplementedCommentServiceServer) AddComment(context.Context, *AddCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedCommentServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedCommentServiceServer) EditComment(context.Context, *EditCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditComment not implemented")
+}
+func (UnimplementedCommentServiceServer) ModerateComment(context.Context, *ModerateCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModerateComment not implemented")
+}
+func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
+func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CommentServiceServer will
+// result in compilation errors.
+type UnsafeCommentServiceServer interface {
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+func RegisterCommentServiceServer(s grpc.ServiceRegistrar, srv CommentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCommentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CommentService_ServiceDesc, srv)
+}
+
+func _CommentService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_EditComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).EditComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_EditComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).EditComment(ctx, req.(*EditCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ModerateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ModerateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ModerateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ModerateComment(ctx, req.(*ModerateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CommentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "web_log.CommentService",
+	HandlerType: (*CommentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddComment",
+			Handler:    _CommentService_AddComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _CommentService_ListComments_Handler,
+		},
+		{
+			MethodName: "EditComment",
+			Handler:    _CommentService_EditComment_Handler,
+		},
+		{
+			MethodName: "ModerateComment",
+			Handler:    _CommentService_ModerateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _CommentService_DeleteComment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "web_log/web_log_pb/web_log.proto",
+}
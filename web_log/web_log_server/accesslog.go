@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// access log payload policies
+const (
+	payloadIDs      = "ids"
+	payloadTruncate = "truncate"
+	payloadHash     = "hash"
+	payloadFull     = "full"
+)
+
+// accessLogConfig is the "accessLog" section of conf.json
+type accessLogConfig struct {
+	// how much of an article is written to access.log: ids, truncate, hash or full
+	Payload string `json:"payload"`
+	// bytes of content kept by the truncate policy
+	TruncateBytes int `json:"truncateBytes"`
+	// regular expressions, the matches in titles and contents are replaced by [REDACTED]
+	Redact []string `json:"redact"`
+}
+
+// Check the policy and compile the redact patterns
+func (config accessLogConfig) compile() ([]*regexp.Regexp, error) {
+	switch config.Payload {
+	case payloadIDs, payloadHash, payloadFull:
+	case payloadTruncate:
+		if config.TruncateBytes <= 0 {
+			return nil, fmt.Errorf("truncateBytes must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("payload %q is not ids, truncate, hash or full", config.Payload)
+	}
+	patterns := make([]*regexp.Regexp, len(config.Redact))
+	for i, expr := range config.Redact {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("redact[%d]: %v", i, err)
+		}
+		patterns[i] = pattern
+	}
+	return patterns, nil
+}
+
+// payloadPolicy formats articles for access.log, it can be changed while the server is running
+type payloadPolicy struct {
+	mu       sync.RWMutex
+	config   accessLogConfig
+	patterns []*regexp.Regexp
+}
+
+var accessPayload = &payloadPolicy{config: accessLogConfig{Payload: payloadIDs}}
+
+// Replace the policy, the config was checked by validate
+func (p *payloadPolicy) setConfig(config accessLogConfig) {
+	patterns, err := config.compile()
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.patterns = patterns
+}
+
+// Replace every match of the redact patterns
+func (p *payloadPolicy) redact(s string) string {
+	for _, pattern := range p.patterns {
+		s = pattern.ReplaceAllString(s, "[REDACTED]")
+	}
+	return s
+}
+
+// Cut s to at most n bytes without splitting a UTF-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// Format the articles of one request as space separated fields
+func (p *payloadPolicy) format(articles ...Article) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	fields := make([]string, 0, len(articles))
+	for _, article := range articles {
+		field := "articleID=" + article.ArticleID
+		switch p.config.Payload {
+		case payloadTruncate:
+			field += " title=" + strconv.Quote(p.redact(article.Title)) +
+				" content=" + strconv.Quote(truncate(p.redact(article.Content), p.config.TruncateBytes))
+		case payloadHash:
+			sum := sha256.Sum256([]byte(article.Content))
+			field += " title=" + strconv.Quote(p.redact(article.Title)) + " sha256=" + hex.EncodeToString(sum[:])
+		case payloadFull:
+			field += " title=" + strconv.Quote(p.redact(article.Title)) + " content=" + strconv.Quote(p.redact(article.Content))
+		}
+		fields = append(fields, field)
+	}
+	return strings.Join(fields, " ")
+}
+
+// accessRecord collects the fields of one RPC for its access.log line
+type accessRecord struct {
+	mu        sync.Mutex
+	start     time.Time
+	principal string
+	payload   string
+	recvMsgs  int
+	sentMsgs  int
+	recvBytes int
+	sentBytes int
+}
+
+type accessRecordKey struct{}
+
+// Get the access record of the RPC, nil outside of the access log interceptor
+func getAccessRecord(ctx context.Context) *accessRecord {
+	r, _ := ctx.Value(accessRecordKey{}).(*accessRecord)
+	return r
+}
+
+// Set the authenticated principal of the RPC
+func setAccessPrincipal(ctx context.Context, principal string) {
+	if r := getAccessRecord(ctx); r != nil {
+		r.mu.Lock()
+		r.principal = principal
+		r.mu.Unlock()
+	}
+}
+
+// Set the articles part of the access.log line, see payloadPolicy
+func setAccessPayload(ctx context.Context, payload string) {
+	if r := getAccessRecord(ctx); r != nil {
+		r.mu.Lock()
+		r.payload = payload
+		r.mu.Unlock()
+	}
+}
+
+// Start the access record of an HTTP request, with its headers as incoming metadata and its client as peer
+// like a gRPC call, so authenticator.check and accessRecord.write work the same way
+func startHTTPAccess(r *http.Request) (context.Context, *accessRecord) {
+	record := &accessRecord{start: time.Now()}
+	ctx := context.WithValue(r.Context(), accessRecordKey{}, record)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(
+		"authorization", r.Header.Get("Authorization"),
+		"user-agent", r.Header.Get("User-Agent"),
+	))
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return ctx, record
+}
+
+// Count a received or sent message
+func (r *accessRecord) count(m interface{}, received bool) {
+	size := 0
+	if msg, ok := m.(proto.Message); ok {
+		size = proto.Size(msg)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if received {
+		r.recvMsgs++
+		r.recvBytes += size
+	} else {
+		r.sentMsgs++
+		r.sentBytes += size
+	}
+}
+
+// Get the user agent of the client from the metadata
+func getUserAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) != 0 {
+			return ua[0]
+		}
+	}
+	return ""
+}
+
+// Write the access.log line of a finished RPC
+func (r *accessRecord) write(ctx context.Context, fullMethod string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	principal := r.principal
+	if principal == "" {
+		principal = "-"
+	}
+	fields := []interface{}{
+		"start=" + r.start.Format(time.RFC3339Nano),
+		"duration=" + time.Since(r.start).String(),
+		"clientIP=" + getClientIP(ctx),
+		"principal=" + principal,
+		"method=" + fullMethod,
+		"code=" + status.Code(err).String(),
+		"reqBytes=" + strconv.Itoa(r.recvBytes),
+		"respBytes=" + strconv.Itoa(r.sentBytes),
+		"recvMsgs=" + strconv.Itoa(r.recvMsgs),
+		"sentMsgs=" + strconv.Itoa(r.sentMsgs),
+		"userAgent=" + strconv.Quote(getUserAgent(ctx)),
+	}
+	if traceID := getTraceID(ctx); traceID != "" {
+		fields = append(fields, "traceID="+traceID)
+	}
+	if r.payload != "" {
+		fields = append(fields, r.payload)
+	}
+	accessWebLogger.Access(fields...)
+}
+
+// accessUnaryInterceptor writes one access.log line per unary RPC
+func accessUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r := &accessRecord{start: time.Now()}
+	r.count(req, true)
+	res, err := handler(context.WithValue(ctx, accessRecordKey{}, r), req)
+	if err == nil {
+		r.count(res, false)
+	}
+	r.write(ctx, info.FullMethod, err)
+	return res, err
+}
+
+// accessStreamInterceptor writes one access.log line per streaming RPC
+func accessStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	r := &accessRecord{start: time.Now()}
+	ctx := context.WithValue(ss.Context(), accessRecordKey{}, r)
+	err := handler(srv, &accessStream{ServerStream: ss, ctx: ctx, record: r})
+	r.write(ctx, info.FullMethod, err)
+	return err
+}
+
+// accessStream counts the messages of a stream
+type accessStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	record *accessRecord
+}
+
+func (s *accessStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *accessStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.record.count(m, true)
+	}
+	return err
+}
+
+func (s *accessStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.record.count(m, false)
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestPayloadPolicies(t *testing.T) {
+	article := Article{ArticleID: "a1", Title: "Secret plan", Content: "héllo secret world"}
+	sum := sha256.Sum256([]byte(article.Content))
+	for _, test := range []struct {
+		config accessLogConfig
+		want   string
+	}{
+		{accessLogConfig{Payload: payloadIDs, Redact: []string{"(?i)secret"}}, `articleID=a1`},
+		{accessLogConfig{Payload: payloadTruncate, TruncateBytes: 2}, `articleID=a1 title="Secret plan" content="h..."`},
+		{accessLogConfig{Payload: payloadTruncate, TruncateBytes: 3}, `articleID=a1 title="Secret plan" content="hé..."`},
+		{accessLogConfig{Payload: payloadTruncate, TruncateBytes: 100, Redact: []string{"(?i)secret"}}, `articleID=a1 title="[REDACTED] plan" content="héllo [REDACTED] world"`},
+		{accessLogConfig{Payload: payloadHash, Redact: []string{"plan"}}, `articleID=a1 title="Secret [REDACTED]" sha256=` + hex.EncodeToString(sum[:])},
+		{accessLogConfig{Payload: payloadFull}, `articleID=a1 title="Secret plan" content="héllo secret world"`},
+	} {
+		p := &payloadPolicy{}
+		p.setConfig(test.config)
+		if got := p.format(article); got != test.want {
+			t.Errorf("%+v: format is %s, want %s", test.config, got, test.want)
+		}
+	}
+
+	p := &payloadPolicy{}
+	p.setConfig(accessLogConfig{Payload: payloadIDs})
+	if got := p.format(article, Article{ArticleID: "a2"}); got != "articleID=a1 articleID=a2" {
+		t.Errorf("format of two articles is %s", got)
+	}
+}
+
+func TestPayloadConfigIsChecked(t *testing.T) {
+	for _, config := range []accessLogConfig{
+		{Payload: "all"},
+		{Payload: payloadTruncate},
+		{Payload: payloadFull, Redact: []string{"("}},
+	} {
+		if _, err := config.compile(); err == nil {
+			t.Errorf("%+v was accepted", config)
+		}
+	}
+
+	// an invalid policy is not applied
+	p := &payloadPolicy{}
+	p.setConfig(accessLogConfig{Payload: payloadIDs})
+	p.setConfig(accessLogConfig{Payload: payloadFull, Redact: []string{"("}})
+	if got := p.format(Article{ArticleID: "a1", Title: "t", Content: "c"}); got != "articleID=a1" {
+		t.Errorf("format after an invalid policy is %s", got)
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"grpc_web_log/web_log/web_log_pb"
+	"io"
+	"io/fs"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminConfig is the "admin" section of conf.json
+type adminConfig struct {
+	Enabled bool   `json:"enabled"`
+	Addr    string `json:"addr"`
+}
+
+// the admin UI page
+//
+//go:embed admin
+var adminFiles embed.FS
+
+// articles per page of the admin UI, and the largest page size
+const (
+	adminPageSize    = 20
+	adminMaxPageSize = 100
+)
+
+// deleted articles kept for restore, the oldest are forgotten first
+const adminTrashSize = 100
+
+// largest text file accepted by import
+const adminMaxImportBytes = 16 * 1024 * 1024
+
+// the admin API calls are authorized like these RPCs, with the same tokens and roles
+const (
+	methodGetAll = "/web_log.WebLogService/GetAllArticles"
+	methodGet    = "/web_log.WebLogService/GetSpecifiedArticle"
+	methodSave   = "/web_log.WebLogService/SaveAllArticles"
+	methodUpdate = "/web_log.WebLogService/UpdateSpecifiedArticle"
+	methodRemove = "/web_log.WebLogService/RemoveSpecifiedArticle"
+)
+
+// adminServer serves the admin UI and its JSON API on top of the article store
+type adminServer struct {
+	authn *authenticator
+	mu    sync.Mutex
+	// deleted articles, newest last
+	trash Articles
+}
+
+// articlePage is a page of the article list
+type articlePage struct {
+	Articles Articles `json:"articles"`
+	Total    int      `json:"total"`
+	Page     int      `json:"page"`
+	Size     int      `json:"size"`
+}
+
+// Serve the admin UI on the configured address
+func serveAdmin(config adminConfig, authn *authenticator) *http.Server {
+	admin := &adminServer{authn: authn}
+	static, _ := fs.Sub(adminFiles, "admin")
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin/", http.FileServer(http.FS(static))))
+	mux.HandleFunc("/admin/api/", admin.serveAPI)
+	mux.Handle("/", http.RedirectHandler("/admin/", http.StatusFound))
+	srv := &http.Server{Addr: config.Addr, Handler: mux}
+	pc, _, _, _ := runtime.Caller(0)
+	serveHTTP(srv, getCurrentRPCmethod(pc), "Failed to serve admin UI.")
+	return srv
+}
+
+// Route an API call, write its access.log line and report its error as JSON
+func (a *adminServer) serveAPI(w http.ResponseWriter, r *http.Request) {
+	ctx, record := startHTTPAccess(r)
+
+	route := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/api/"), "/")
+	parts := strings.Split(route, "/")
+	var res interface{}
+	var err error
+	switch {
+	case route == "articles" && r.Method == http.MethodGet:
+		res, err = a.list(ctx, r)
+	case route == "import" && r.Method == http.MethodPost:
+		res, err = a.importArticles(ctx, http.MaxBytesReader(w, r.Body, adminMaxImportBytes))
+	case route == "trash" && r.Method == http.MethodGet:
+		res, err = a.listTrash(ctx)
+	case len(parts) == 2 && parts[0] == "articles" && r.Method == http.MethodGet:
+		res, err = a.get(ctx, parts[1])
+	case len(parts) == 2 && parts[0] == "articles" && r.Method == http.MethodPut:
+		res, err = a.update(ctx, parts[1], r.Body)
+	case len(parts) == 2 && parts[0] == "articles" && r.Method == http.MethodDelete:
+		res, err = a.remove(ctx, parts[1])
+	case len(parts) == 3 && parts[0] == "trash" && parts[2] == "restore" && r.Method == http.MethodPost:
+		res, err = a.restore(ctx, parts[1])
+	default:
+		err = status.Error(codes.NotFound, "no such admin API call")
+	}
+	record.write(ctx, "/admin/api/"+route, err)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		st := status.Convert(err)
+		w.WriteHeader(gwruntime.HTTPStatusFromCode(st.Code()))
+		json.NewEncoder(w).Encode(map[string]string{"error": st.Message()})
+		return
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+// List a page of articles, ?page= starts at 1
+func (a *adminServer) list(ctx context.Context, r *http.Request) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodGetAll)
+	if err != nil {
+		return nil, err
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+	if size < 1 || size > adminMaxPageSize {
+		size = adminPageSize
+	}
+
+	articles := visibleArticles(ctx, store.all(ctx))
+	res := articlePage{Articles: Articles{}, Total: len(articles), Page: page, Size: size}
+	start := (page - 1) * size
+	if start < len(articles) {
+		end := start + size
+		if end > len(articles) {
+			end = len(articles)
+		}
+		res.Articles = articles[start:end]
+	}
+	return res, nil
+}
+
+func (a *adminServer) get(ctx context.Context, articleID string) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodGet)
+	if err != nil {
+		return nil, err
+	}
+	article, ok := store.get(ctx, articleID)
+	if !ok || !canSeeArticle(ctx, article) {
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	return article, nil
+}
+
+func (a *adminServer) update(ctx context.Context, articleID string, body io.Reader) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodUpdate)
+	if err != nil {
+		return nil, err
+	}
+	var article Article
+	if err := json.NewDecoder(body).Decode(&article); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "body is not an article: "+err.Error())
+	}
+	article.ArticleID = articleID
+	if err := checkContentFormat(article.ContentFormat); err != nil {
+		return nil, err
+	}
+	// the body is the whole article, its title and content replace the current ones
+	updated, isExist, err := store.update(ctx, articleID, setContent(&article.Title, &article.Content, &article.ContentFormat))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "article could not be updated")
+	}
+	if !isExist {
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	setAccessPayload(ctx, accessPayload.format(article))
+	return updated, nil
+}
+
+// Remove an article and keep it in the trash
+func (a *adminServer) remove(ctx context.Context, articleID string) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodRemove)
+	if err != nil {
+		return nil, err
+	}
+	article, ok := store.get(ctx, articleID)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	// a restored article comes back without its comments, they go first so a failure keeps the article
+	if _, err := comments.removeArticle(ctx, articleID); err != nil {
+		return nil, status.Error(codes.Internal, "comments of the article could not be removed")
+	}
+	if _, err := store.remove(ctx, articleID); err != nil {
+		return nil, status.Error(codes.Internal, "article could not be removed")
+	}
+
+	a.mu.Lock()
+	a.trash = append(a.trash, article)
+	if len(a.trash) > adminTrashSize {
+		a.trash = a.trash[len(a.trash)-adminTrashSize:]
+	}
+	a.mu.Unlock()
+	setAccessPayload(ctx, "articleID="+articleID)
+	return article, nil
+}
+
+func (a *adminServer) listTrash(ctx context.Context) (interface{}, error) {
+	if _, err := a.authn.check(ctx, methodRemove); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append(Articles{}, a.trash...), nil
+}
+
+// Put a deleted article back with its articleID
+func (a *adminServer) restore(ctx context.Context, articleID string) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodRemove)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := store.get(ctx, articleID); ok {
+		return nil, status.Error(codes.AlreadyExists, "articleID is already existed.")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, article := range a.trash {
+		if article.ArticleID != articleID {
+			continue
+		}
+		if err := store.add(ctx, article); err != nil {
+			return nil, status.Error(codes.Internal, "article could not be restored")
+		}
+		a.trash = append(a.trash[:i], a.trash[i+1:]...)
+		setAccessPayload(ctx, "articleID="+articleID)
+		return article, nil
+	}
+	return nil, status.Error(codes.NotFound, "articleID is NOT in the trash.")
+}
+
+// Save the articles of a text file in the conf/articles.txt format, with the defaults of SaveAllArticles
+func (a *adminServer) importArticles(ctx context.Context, body io.Reader) (interface{}, error) {
+	ctx, err := a.authn.check(ctx, methodSave)
+	if err != nil {
+		return nil, err
+	}
+	articles, err := parseArticlesText(body)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, status.Error(codes.ResourceExhausted, "file exceeds "+strconv.FormatInt(tooLarge.Limit, 10)+" bytes")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(articles) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "It is an empty file.")
+	}
+	for i := range articles {
+		if err := checkContentFormat(articles[i].ContentFormat); err != nil {
+			return nil, err
+		}
+		if err := classify(&articles[i], nil, ""); err != nil {
+			return nil, err
+		}
+		if err := setInitialStatus(&articles[i], web_log_pb.ArticleStatus_ARTICLE_STATUS_UNSPECIFIED, ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := store.add(ctx, articles...); err != nil {
+		return nil, status.Error(codes.Internal, "articles could not be saved")
+	}
+	setAccessPayload(ctx, accessPayload.format(articles...))
+	return articles, nil
+}
+
+// Parse articles separated by empty lines, the first line of each is the title and the others the content
+func parseArticlesText(r io.Reader) (Articles, error) {
+	var articles Articles
+	var lines []string
+	flush := func() {
+		if len(lines) != 0 {
+			articles = append(articles, Article{
+				ArticleID: generateUUID(),
+				Title:     lines[0],
+				Content:   strings.Join(lines[1:], ""),
+			})
+		}
+		lines = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			flush()
+		} else {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	flush()
+	return articles, scanner.Err()
+}
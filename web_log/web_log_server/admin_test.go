@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Serve the admin API without authentication on a fresh store
+func newAdminTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	st := openTestStore(t, t.TempDir())
+	t.Cleanup(func() { abandon(st) })
+	store = st
+	admin := &adminServer{authn: newAuthenticator(authConfig{})}
+	ts := httptest.NewServer(http.HandlerFunc(admin.serveAPI))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestAdminImportSetsDefaults(t *testing.T) {
+	ts := newAdminTestServer(t)
+	res, err := http.Post(ts.URL+"/admin/api/import", "text/plain", strings.NewReader("title\ncontent\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("import returned %s", res.Status)
+	}
+	articles := store.all(context.Background())
+	if len(articles) != 1 || articles[0].Status != statusPublished || articles[0].Version != 1 {
+		t.Errorf("imported %+v, want one published article", articles)
+	}
+}
+
+func TestAdminImportTooLarge(t *testing.T) {
+	ts := newAdminTestServer(t)
+	body := strings.Repeat("line\n", adminMaxImportBytes/5+1)
+	res, err := http.Post(ts.URL+"/admin/api/import", "text/plain", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("import of %d bytes returned %s, want 429", len(body), res.Status)
+	}
+	if articles := store.all(context.Background()); len(articles) != 0 {
+		t.Errorf("saved %d articles of a file too large", len(articles))
+	}
+}
+
+func TestAdminRemoveKeepsArticleWhenCommentsFail(t *testing.T) {
+	ts := newAdminTestServer(t)
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "a", Content: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	// the comment file is a directory, so removing the comments fails
+	previous := comments
+	comments = &commentStore{path: t.TempDir(), comments: []Comment{{CommentID: "c", ArticleID: "a"}}}
+	defer func() { comments = previous }()
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/admin/api/articles/a", nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("remove returned %s, want 500", res.Status)
+	}
+	if _, ok := store.get(ctx, "a"); !ok {
+		t.Error("article was removed although its comments were not")
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"path"
+	"strings"
+	"sync"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authConfig is the "auth" section of conf.json
+type authConfig struct {
+	Enabled   bool     `json:"enabled"`
+	APIKeys   []apiKey `json:"apiKeys"`
+	JWTSecret string   `json:"jwtSecret"`
+}
+
+// apiKey is a static bearer token bound to a principal and a role
+type apiKey struct {
+	Key       string `json:"key"`
+	Principal string `json:"principal"`
+	Role      string `json:"role"`
+}
+
+// roles, from the least to the most privileged
+const (
+	roleReader = "reader"
+	roleEditor = "editor"
+	roleAdmin  = "admin"
+)
+
+var roleRank = map[string]int{
+	roleReader: 1,
+	roleEditor: 2,
+	roleAdmin:  3,
+}
+
+// the lowest role allowed to call each WebLogService and CommentService method
+var methodRoles = map[string]string{
+	"/web_log.WebLogService/GetAllArticles":         roleReader,
+	"/web_log.WebLogService/GetSpecifiedArticle":    roleReader,
+	"/web_log.WebLogService/SaveAllArticles":        roleEditor,
+	"/web_log.WebLogService/UpdateSpecifiedArticle": roleEditor,
+	"/web_log.WebLogService/RemoveSpecifiedArticle": roleAdmin,
+	"/web_log.WebLogService/RenderArticle":          roleReader,
+	"/web_log.WebLogService/AddTags":                roleEditor,
+	"/web_log.WebLogService/RemoveTags":             roleEditor,
+	"/web_log.WebLogService/ListTags":               roleReader,
+	"/web_log.WebLogService/ListArticles":           roleReader,
+	"/web_log.WebLogService/SetArticleStatus":       roleEditor,
+	"/web_log.WebLogService/WatchArticles":          roleReader,
+	// authors may edit and delete their own comments, editors any comment
+	"/web_log.CommentService/AddComment":      roleReader,
+	"/web_log.CommentService/ListComments":    roleReader,
+	"/web_log.CommentService/EditComment":     roleReader,
+	"/web_log.CommentService/ModerateComment": roleEditor,
+	"/web_log.CommentService/DeleteComment":   roleReader,
+	// server reflection, when enabled in conf.json
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      roleReader,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": roleReader,
+}
+
+// methods that can be called without a token
+var publicMethods = map[string]bool{
+	"/grpc.health.v1.Health/Check": true,
+	"/grpc.health.v1.Health/Watch": true,
+}
+
+// principal is the authenticated caller of an RPC
+type principal struct {
+	Name string
+	Role string
+}
+
+// principal used for every call when auth is disabled, a reader so drafts stay hidden and limits apply per client IP
+var anonymousPrincipal = principal{Name: "anonymous", Role: roleReader}
+
+type principalKey struct{}
+
+// tokenClaims are the claims expected in an HMAC-signed JWT, a token without exp is refused
+type tokenClaims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// authenticator checks bearer tokens and per-method roles
+type authenticator struct {
+	mu     sync.RWMutex
+	config authConfig
+}
+
+func newAuthenticator(config authConfig) *authenticator {
+	return &authenticator{config: config}
+}
+
+// Replace the API keys and JWT secret when conf.json is reloaded
+func (a *authenticator) setConfig(config authConfig) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = config
+}
+
+func (a *authenticator) currentConfig() authConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config
+}
+
+// Get the authenticated principal name for weblogger
+func getPrincipal(ctx context.Context) string {
+	if p, ok := ctx.Value(principalKey{}).(principal); ok {
+		return p.Name
+	}
+	return ""
+}
+
+// Check whether the authenticated principal has at least the role
+func hasRole(ctx context.Context, role string) bool {
+	p, ok := ctx.Value(principalKey{}).(principal)
+	return ok && roleRank[p.Role] >= roleRank[role]
+}
+
+// Read the bearer token from the authorization metadata
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	const prefix = "bearer "
+	if len(values[0]) <= len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(values[0][len(prefix):]), true
+}
+
+// Look the token up in the static API keys, then try it as a JWT
+func (a *authenticator) authenticate(ctx context.Context, config authConfig) (principal, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return principal{}, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	for _, key := range config.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key.Key), []byte(token)) == 1 {
+			return principal{Name: key.Principal, Role: key.Role}, nil
+		}
+	}
+
+	if config.JWTSecret == "" {
+		return principal{}, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(config.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}), jwt.WithExpirationRequired())
+	if err != nil {
+		return principal{}, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if claims.Subject == "" {
+		return principal{}, status.Error(codes.Unauthenticated, "token has no subject")
+	}
+	return principal{Name: claims.Subject, Role: claims.Role}, nil
+}
+
+// Check whether the principal's role may call the method
+func authorize(p principal, fullMethod string) error {
+	required, ok := methodRoles[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed", fullMethod)
+	}
+	if roleRank[p.Role] < roleRank[required] {
+		return status.Errorf(codes.PermissionDenied, "role %q cannot call %s", p.Role, path.Base(fullMethod))
+	}
+	return nil
+}
+
+// Authenticate and authorize the caller, and return a context carrying the principal
+func (a *authenticator) check(ctx context.Context, fullMethod string) (context.Context, error) {
+	config := a.currentConfig()
+	if !config.Enabled || publicMethods[fullMethod] {
+		setAccessPrincipal(ctx, anonymousPrincipal.Name)
+		return context.WithValue(ctx, principalKey{}, anonymousPrincipal), nil
+	}
+
+	p, err := a.authenticate(ctx, config)
+	if err == nil {
+		err = authorize(p, fullMethod)
+	}
+	if err != nil {
+		errorLogOf(ctx).ErrorPrintln(path.Base(fullMethod), status.Convert(err).Message())
+		return nil, err
+	}
+	setAccessPrincipal(ctx, p.Name)
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+// unaryInterceptor checks bearer tokens for unary RPCs
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor checks bearer tokens for streaming RPCs
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.check(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream is a grpc.ServerStream with a replaced context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"grpc_web_log/web_log/web_log_pb"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testJWTSecret = "test-jwt-secret"
+
+// Sign claims with a method and key
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// Get an incoming context with the authorization metadata, none when it is empty
+func withAuthorization(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+func TestAuthenticatorCheck(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	authn := newAuthenticator(authConfig{
+		Enabled:   true,
+		APIKeys:   []apiKey{{Key: "reader-key", Principal: "reader", Role: roleReader}, {Key: "admin-key", Principal: "admin", Role: roleAdmin}},
+		JWTSecret: testJWTSecret,
+	})
+	const (
+		getAll = "/web_log.WebLogService/GetAllArticles"
+		save   = "/web_log.WebLogService/SaveAllArticles"
+		remove = "/web_log.WebLogService/RemoveSpecifiedArticle"
+	)
+
+	for _, test := range []struct {
+		name          string
+		authorization string
+		method        string
+		want          codes.Code
+		principal     string
+	}{
+		{"no token", "", getAll, codes.Unauthenticated, ""},
+		{"not bearer", "Basic cmVhZGVyOmtleQ==", getAll, codes.Unauthenticated, ""},
+		{"empty bearer", "Bearer ", getAll, codes.Unauthenticated, ""},
+		{"unknown key", "Bearer other-key", getAll, codes.Unauthenticated, ""},
+		{"api key", "Bearer reader-key", getAll, codes.OK, "reader"},
+		{"bearer in any case", "bearer admin-key", remove, codes.OK, "admin"},
+		{"reader calls editor method", "Bearer reader-key", save, codes.PermissionDenied, ""},
+		{"reader calls admin method", "Bearer reader-key", remove, codes.PermissionDenied, ""},
+		{"unknown method", "Bearer admin-key", "/web_log.WebLogService/Other", codes.PermissionDenied, ""},
+		{"health is public", "", "/grpc.health.v1.Health/Check", codes.OK, "anonymous"},
+		{"jwt", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), jwt.MapClaims{"sub": "jane", "role": roleEditor, "exp": exp}), save, codes.OK, "jane"},
+		{"jwt with reader role", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), jwt.MapClaims{"sub": "jane", "role": roleReader, "exp": exp}), save, codes.PermissionDenied, ""},
+		{"jwt without exp", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), jwt.MapClaims{"sub": "jane", "role": roleAdmin}), getAll, codes.Unauthenticated, ""},
+		{"expired jwt", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), jwt.MapClaims{"sub": "jane", "role": roleAdmin, "exp": time.Now().Add(-time.Minute).Unix()}), getAll, codes.Unauthenticated, ""},
+		{"jwt without sub", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), jwt.MapClaims{"role": roleAdmin, "exp": exp}), getAll, codes.Unauthenticated, ""},
+		{"jwt with another secret", "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), jwt.MapClaims{"sub": "jane", "role": roleAdmin, "exp": exp}), getAll, codes.Unauthenticated, ""},
+		{"jwt signed with none", "Bearer " + signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "jane", "role": roleAdmin, "exp": exp}), getAll, codes.Unauthenticated, ""},
+		{"jwt signed with RS256", "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, jwt.MapClaims{"sub": "jane", "role": roleAdmin, "exp": exp}), getAll, codes.Unauthenticated, ""},
+	} {
+		ctx, err := authn.check(withAuthorization(test.authorization), test.method)
+		if status.Code(err) != test.want {
+			t.Errorf("%s: check returned %v, want %v", test.name, err, test.want)
+			continue
+		}
+		if err == nil && getPrincipal(ctx) != test.principal {
+			t.Errorf("%s: principal is %q, want %q", test.name, getPrincipal(ctx), test.principal)
+		}
+	}
+}
+
+func TestAuthDisabledIsAnonymousReader(t *testing.T) {
+	ctx, err := newAuthenticator(authConfig{}).check(context.Background(), "/web_log.WebLogService/GetAllArticles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getPrincipal(ctx) != "anonymous" || !hasRole(ctx, roleReader) || hasRole(ctx, roleEditor) {
+		t.Errorf("auth disabled gives principal %q, want an anonymous reader", getPrincipal(ctx))
+	}
+}
+
+func TestEveryMethodHasARole(t *testing.T) {
+	for _, desc := range []grpc.ServiceDesc{web_log_pb.WebLogService_ServiceDesc, web_log_pb.CommentService_ServiceDesc} {
+		var names []string
+		for _, method := range desc.Methods {
+			names = append(names, method.MethodName)
+		}
+		for _, stream := range desc.Streams {
+			names = append(names, stream.StreamName)
+		}
+		for _, name := range names {
+			fullMethod := "/" + desc.ServiceName + "/" + name
+			role, ok := methodRoles[fullMethod]
+			if !ok {
+				t.Errorf("%s has no role", fullMethod)
+			} else if _, ok := roleRank[role]; !ok {
+				t.Errorf("%s has unknown role %q", fullMethod, role)
+			}
+		}
+	}
+	for fullMethod, role := range methodRoles {
+		if _, ok := roleRank[role]; !ok {
+			t.Errorf("%s has unknown role %q", fullMethod, role)
+		}
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		change  func(config *configuration)
+		problem string
+	}{
+		{"auth off", func(config *configuration) {}, ""},
+		{"placeholder key", func(config *configuration) {
+			config.Auth = authConfig{Enabled: true, APIKeys: []apiKey{{Key: "admin-change-me", Principal: "admin", Role: roleAdmin}}}
+		}, "placeholder key"},
+		{"placeholder key with auth off", func(config *configuration) {
+			config.Auth = authConfig{APIKeys: []apiKey{{Key: "admin-change-me", Principal: "admin", Role: roleAdmin}}}
+		}, ""},
+		{"placeholder secret", func(config *configuration) {
+			config.Auth = authConfig{Enabled: true, JWTSecret: "jwt-CHANGE-ME"}
+		}, "jwtSecret is still a placeholder"},
+		{"no keys or secret", func(config *configuration) {
+			config.Auth = authConfig{Enabled: true}
+		}, "without apiKeys or a jwtSecret"},
+		{"key without principal", func(config *configuration) {
+			config.Auth = authConfig{Enabled: true, APIKeys: []apiKey{{Key: "k", Role: roleAdmin}}}
+		}, "needs a key and a principal"},
+		{"unknown role", func(config *configuration) {
+			config.Auth = authConfig{Enabled: true, APIKeys: []apiKey{{Key: "k", Principal: "p", Role: "owner"}}}
+		}, `unknown role "owner"`},
+		{"public admin without auth", func(config *configuration) {
+			config.Admin = adminConfig{Enabled: true, Addr: "0.0.0.0:8082"}
+		}, "admin.addr is not a loopback address"},
+		{"public admin with auth", func(config *configuration) {
+			config.Admin = adminConfig{Enabled: true, Addr: "0.0.0.0:8082"}
+			config.Auth = authConfig{Enabled: true, APIKeys: []apiKey{{Key: "k", Principal: "p", Role: roleAdmin}}}
+		}, ""},
+	} {
+		config := defaultConfiguration()
+		test.change(&config)
+		err := config.validate()
+		switch {
+		case test.problem == "" && err != nil:
+			t.Errorf("%s: validate returned %v", test.name, err)
+		case test.problem != "" && (err == nil || !strings.Contains(err.Error(), test.problem)):
+			t.Errorf("%s: validate returned %v, want %q", test.name, err, test.problem)
+		}
+	}
+}
+
+func TestGRPCAddr(t *testing.T) {
+	config := defaultConfiguration()
+	if addr := config.grpcAddr(); addr != "127.0.0.1:50051" {
+		t.Errorf("without auth gRPC listens on %s", addr)
+	}
+	config.Auth.Enabled = true
+	if addr := config.grpcAddr(); addr != "0.0.0.0:50051" {
+		t.Errorf("with auth gRPC listens on %s", addr)
+	}
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"grpc_web_log/web_log/web_log_pb"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// default comments file path
+const commentsFile = "conf/comments.json"
+
+// longest comment
+const maxCommentRunes = 4000
+
+// ListComments page sizes
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+// moderation status of a comment in comments.json
+const (
+	commentPending  = "pending"
+	commentApproved = "approved"
+	commentSpam     = "spam"
+)
+
+// Comment is a comment on an article, or a reply to another comment
+type Comment struct {
+	CommentID string `json:"commentID"`
+	ArticleID string `json:"articleID"`
+	// empty for a top-level comment
+	ParentID string `json:"parentID,omitempty"`
+	// principal who wrote the comment
+	Author  string `json:"author"`
+	Content string `json:"content"`
+	// pending, approved or spam
+	Status  string    `json:"status"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// commentStore keeps the comments of comments.json in memory, every change rewrites the file
+type commentStore struct {
+	mu       sync.Mutex
+	path     string
+	comments []Comment
+}
+
+// comments of the articles, saved in comments.json
+var comments *commentStore
+
+// Open the comment store, a missing file has no comments
+func openCommentStore(path string) (*commentStore, error) {
+	cs := &commentStore{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, &cs.comments); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+// Write the comments to a temp file which replaces comments.json, must hold cs.mu
+func (cs *commentStore) write(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "commentStore.write")
+	defer span.End()
+	data, _ := json.MarshalIndent(cs.comments, "", "  ")
+	err := writeFileSync(cs.path+".tmp", data)
+	if err == nil {
+		err = os.Rename(cs.path+".tmp", cs.path)
+	}
+	if err == nil {
+		err = syncDir(cs.path)
+	}
+	if err != nil {
+		span.RecordError(err)
+		pc, _, _, _ := runtime.Caller(0)
+		errorLogOf(ctx).Error(getCurrentRPCmethod(pc), "Write to comments file error.", err)
+	}
+	return err
+}
+
+// Position of a comment, -1 if the commentID does not exist, must hold cs.mu
+func (cs *commentStore) find(commentID string) int {
+	for i, comment := range cs.comments {
+		if comment.CommentID == commentID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get the comment with the commentID
+func (cs *commentStore) get(commentID string) (Comment, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if i := cs.find(commentID); i >= 0 {
+		return cs.comments[i], true
+	}
+	return Comment{}, false
+}
+
+// Get the comments of an article by creation time
+func (cs *commentStore) forArticle(articleID string) []Comment {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	var found []Comment
+	for _, comment := range cs.comments {
+		if comment.ArticleID == articleID {
+			found = append(found, comment)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Created.Before(found[j].Created) })
+	return found
+}
+
+// Add a comment, a reply must be on the article of its parent and the caller must see the parent
+func (cs *commentStore) add(ctx context.Context, comment Comment) (Comment, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if comment.ParentID != "" {
+		i := cs.find(comment.ParentID)
+		if i < 0 || cs.comments[i].ArticleID != comment.ArticleID || !canSeeComment(ctx, cs.comments[i]) {
+			return Comment{}, status.Error(codes.NotFound, "parentID is NOT existed.")
+		}
+	}
+	comment.CommentID = generateUUID()
+	comment.Created = time.Now().UTC()
+	comment.Updated = comment.Created
+	cs.comments = append(cs.comments, comment)
+	if err := cs.write(ctx); err != nil {
+		cs.comments = cs.comments[:len(cs.comments)-1]
+		return Comment{}, status.Error(codes.Internal, "comment could not be saved")
+	}
+	return comment, nil
+}
+
+// Change a comment, nothing is written when change returns an error
+func (cs *commentStore) update(ctx context.Context, commentID string, change func(comment *Comment) error) (Comment, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	i := cs.find(commentID)
+	if i < 0 {
+		return Comment{}, status.Error(codes.NotFound, "commentID is NOT existed.")
+	}
+	comment := cs.comments[i]
+	if err := change(&comment); err != nil {
+		return Comment{}, err
+	}
+	comment.Updated = time.Now().UTC()
+	previous := cs.comments[i]
+	cs.comments[i] = comment
+	if err := cs.write(ctx); err != nil {
+		cs.comments[i] = previous
+		return Comment{}, status.Error(codes.Internal, "comment could not be saved")
+	}
+	return comment, nil
+}
+
+// Remove the comments matching remove, and the replies to them, and return how many were removed
+func (cs *commentStore) removeWhere(ctx context.Context, remove func(comment Comment) bool) (int, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	removed := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, comment := range cs.comments {
+			if !removed[comment.CommentID] && (remove(comment) || removed[comment.ParentID]) {
+				removed[comment.CommentID] = true
+				changed = true
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+	previous := cs.comments
+	kept := []Comment{}
+	for _, comment := range cs.comments {
+		if !removed[comment.CommentID] {
+			kept = append(kept, comment)
+		}
+	}
+	cs.comments = kept
+	if err := cs.write(ctx); err != nil {
+		cs.comments = previous
+		return 0, err
+	}
+	return len(removed), nil
+}
+
+// Remove a comment and its replies
+func (cs *commentStore) remove(ctx context.Context, commentID string) (int, error) {
+	return cs.removeWhere(ctx, func(comment Comment) bool { return comment.CommentID == commentID })
+}
+
+// Remove every comment of an article
+func (cs *commentStore) removeArticle(ctx context.Context, articleID string) (int, error) {
+	return cs.removeWhere(ctx, func(comment Comment) bool { return comment.ArticleID == articleID })
+}
+
+// Trim a comment and check its length
+func checkCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", status.Error(codes.InvalidArgument, "content is empty")
+	}
+	if utf8.RuneCountInString(content) > maxCommentRunes {
+		return "", status.Error(codes.InvalidArgument, "a comment is at most "+strconv.Itoa(maxCommentRunes)+" characters")
+	}
+	return content, nil
+}
+
+// Check whether the caller wrote the comment or may moderate it
+func canChangeComment(ctx context.Context, comment Comment) bool {
+	return comment.Author == getPrincipal(ctx) || hasRole(ctx, roleEditor)
+}
+
+// Check whether the caller sees a comment: editors see every comment, readers the approved ones and their own
+func canSeeComment(ctx context.Context, comment Comment) bool {
+	return comment.Status == commentApproved || comment.Author == getPrincipal(ctx) || hasRole(ctx, roleEditor)
+}
+
+// Get the moderation status of a ModerateCommentRequest
+func moderationStatusOf(s web_log_pb.ModerationStatus) (string, error) {
+	switch s {
+	case web_log_pb.ModerationStatus_PENDING:
+		return commentPending, nil
+	case web_log_pb.ModerationStatus_APPROVED:
+		return commentApproved, nil
+	case web_log_pb.ModerationStatus_SPAM:
+		return commentSpam, nil
+	}
+	return "", status.Error(codes.InvalidArgument, "status "+s.String()+" is not pending, approved or spam")
+}
+
+// Convert a comment for a response
+func commentPb(comment Comment) *web_log_pb.Comment {
+	res := &web_log_pb.Comment{
+		CommentID: comment.CommentID,
+		ArticleID: comment.ArticleID,
+		ParentID:  comment.ParentID,
+		Author:    comment.Author,
+		Content:   comment.Content,
+		Created:   comment.Created.Format(time.RFC3339),
+		Updated:   comment.Updated.Format(time.RFC3339),
+	}
+	switch comment.Status {
+	case commentApproved:
+		res.Status = web_log_pb.ModerationStatus_APPROVED
+	case commentSpam:
+		res.Status = web_log_pb.ModerationStatus_SPAM
+	}
+	return res
+}
+
+// Get a page of the top-level comments of an article the caller sees, with their replies.
+// A reply is hidden with the comment it replies to. The page token is the position of the first comment of the page.
+func listComments(ctx context.Context, articleComments []Comment, req *web_log_pb.ListCommentsRequest) (*web_log_pb.ListCommentsResponse, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, status.Error(codes.InvalidArgument, "pageSize must not be negative")
+	case size == 0:
+		size = defaultCommentPageSize
+	case size > maxCommentPageSize:
+		size = maxCommentPageSize
+	}
+	start := 0
+	if req.GetPageToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetPageToken()); err != nil || start < 0 {
+			return nil, status.Error(codes.InvalidArgument, "pageToken is not valid")
+		}
+	}
+
+	replies := make(map[string][]*web_log_pb.Comment)
+	var top []*web_log_pb.Comment
+	for _, comment := range articleComments {
+		if !canSeeComment(ctx, comment) {
+			continue
+		}
+		res := commentPb(comment)
+		if comment.ParentID == "" {
+			top = append(top, res)
+		} else {
+			replies[comment.ParentID] = append(replies[comment.ParentID], res)
+		}
+	}
+	var thread func(c *web_log_pb.Comment)
+	thread = func(c *web_log_pb.Comment) {
+		c.Replies = replies[c.CommentID]
+		for _, reply := range c.Replies {
+			thread(reply)
+		}
+	}
+
+	// a token past the end gives an empty page, start+size cannot overflow
+	if start > len(top) {
+		start = len(top)
+	}
+	res := &web_log_pb.ListCommentsResponse{TotalSize: int32(len(top))}
+	for i := start; i < len(top) && i < start+size; i++ {
+		thread(top[i])
+		res.Comments = append(res.Comments, top[i])
+	}
+	if start+size < len(top) {
+		res.NextPageToken = strconv.Itoa(start + size)
+	}
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"math"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReplyToHiddenCommentIsRefused(t *testing.T) {
+	cs, err := openCommentStore(filepath.Join(t.TempDir(), "comments.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := context.WithValue(context.Background(), principalKey{}, principal{Name: "alice", Role: roleReader})
+	bob := context.WithValue(context.Background(), principalKey{}, principal{Name: "bob", Role: roleReader})
+	pending, err := cs.add(alice, Comment{ArticleID: "a", Author: "alice", Content: "pending", Status: commentPending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cs.add(bob, Comment{ArticleID: "a", ParentID: pending.CommentID, Author: "bob", Status: commentPending}); status.Code(err) != codes.NotFound {
+		t.Errorf("reply to a pending comment of someone else returned %v, want NotFound", err)
+	}
+	if _, err := cs.add(alice, Comment{ArticleID: "a", ParentID: pending.CommentID, Author: "alice", Status: commentPending}); err != nil {
+		t.Errorf("reply to an own pending comment returned %v", err)
+	}
+}
+
+func TestRemoveArticleKeepsItWhenCommentsFail(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "a", Content: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	// the comment file is a directory, so removing the comments fails
+	previous := comments
+	comments = &commentStore{path: t.TempDir(), comments: []Comment{{CommentID: "c", ArticleID: "a"}}}
+	defer func() { comments = previous }()
+
+	_, err := (&server{}).RemoveSpecifiedArticle(ctx, &web_log_pb.RemoveSpecifiedArticleRequest{ArticleID: "a"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("RemoveSpecifiedArticle returned %v, want Internal", err)
+	}
+	if _, ok := store.get(ctx, "a"); !ok {
+		t.Error("article was removed although its comments were not")
+	}
+}
+
+func TestListCommentsPageTokenPastTheEnd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), principalKey{}, principal{Name: "admin", Role: roleAdmin})
+	articleComments := []Comment{
+		{CommentID: "1", ArticleID: "a", Status: commentApproved},
+		{CommentID: "2", ArticleID: "a", Status: commentApproved},
+		{CommentID: "3", ArticleID: "a", ParentID: "2", Status: commentApproved},
+	}
+	for _, token := range []string{"2", "3", strconv.Itoa(math.MaxInt)} {
+		res, err := listComments(ctx, articleComments, &web_log_pb.ListCommentsRequest{ArticleID: "a", PageSize: 1, PageToken: token})
+		if err != nil || len(res.GetComments()) != 0 || res.GetNextPageToken() != "" || res.GetTotalSize() != 2 {
+			t.Errorf("pageToken %s returned %v, %v, want an empty last page", token, res, err)
+		}
+	}
+	res, err := listComments(ctx, articleComments, &web_log_pb.ListCommentsRequest{ArticleID: "a", PageSize: 1, PageToken: "1"})
+	if err != nil || len(res.GetComments()) != 1 || len(res.GetComments()[0].GetReplies()) != 1 || res.GetNextPageToken() != "" {
+		t.Errorf("pageToken 1 returned %v, %v, want comment 2 with its reply", res, err)
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"grpc_web_log/weblogger"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// seconds between two checks of the conf.json mtime
+const configPollInterval = 2
+
+// Configuration used for every setting missing from conf.json
+func defaultConfiguration() configuration {
+	return configuration{
+		Port:          "50051",
+		LogLevel:      "info",
+		LogFormat:     logFormatText,
+		AccessLogPath: accessLogFilePath,
+		ErrorLogPath:  errorLogFilePath,
+		StorePath:     savedJSONFile,
+		WALPath:       walFile,
+		CommentsPath:  commentsFile,
+		AccessLog:     accessLogConfig{Payload: payloadIDs, TruncateBytes: 64},
+		Feeds:         feedsConfig{Title: "web log", Items: defaultFeedItems},
+	}
+}
+
+// Decode conf.json, its relative paths are resolved against dir and the defaults stay relative to the working directory
+func (config *configuration) decodeFile(r io.Reader, dir string) error {
+	defaults := *config
+	config.AccessLogPath, config.ErrorLogPath, config.StorePath, config.WALPath, config.CommentsPath = "", "", "", "", ""
+	if err := json.NewDecoder(r).Decode(config); err != nil {
+		return err
+	}
+	for _, path := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&config.AccessLogPath, defaults.AccessLogPath},
+		{&config.ErrorLogPath, defaults.ErrorLogPath},
+		{&config.StorePath, defaults.StorePath},
+		{&config.WALPath, defaults.WALPath},
+		{&config.CommentsPath, defaults.CommentsPath},
+	} {
+		if *path.value == "" {
+			*path.value = path.fallback
+		} else {
+			*path.value = resolvePath(dir, *path.value)
+		}
+	}
+	for level, path := range config.LevelLogPaths {
+		if path != "" {
+			config.LevelLogPaths[level] = resolvePath(dir, path)
+		}
+	}
+	return nil
+}
+
+// Join a relative path to dir, an absolute path is kept
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// Check the settings, an invalid configuration is never applied
+func (config *configuration) validate() error {
+	var problems []string
+	if port, err := strconv.Atoi(config.Port); err != nil || port <= 0 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("port %q is not a valid TCP port", config.Port))
+	}
+	if _, err := weblogger.ParseLevel(config.LogLevel); err != nil {
+		problems = append(problems, fmt.Sprintf("logLevel %q is not one of debug, info, warn, error, fatal", config.LogLevel))
+	}
+	if config.LogFormat != logFormatText && config.LogFormat != logFormatJSON {
+		problems = append(problems, fmt.Sprintf("logFormat %q is not text or json", config.LogFormat))
+	}
+	for level, path := range config.LevelLogPaths {
+		if _, err := weblogger.ParseLevel(level); err != nil {
+			problems = append(problems, fmt.Sprintf("levelLogPaths has unknown level %q", level))
+		}
+		if path == "" {
+			problems = append(problems, fmt.Sprintf("levelLogPaths.%s is empty", level))
+		}
+	}
+	for name, path := range map[string]string{
+		"accessLogPath": config.AccessLogPath,
+		"errorLogPath":  config.ErrorLogPath,
+		"storePath":     config.StorePath,
+		"walPath":       config.WALPath,
+		"commentsPath":  config.CommentsPath,
+	} {
+		if path == "" {
+			problems = append(problems, name+" is empty")
+		}
+	}
+	for i, key := range config.Auth.APIKeys {
+		if key.Key == "" || key.Principal == "" {
+			problems = append(problems, fmt.Sprintf("auth.apiKeys[%d] needs a key and a principal", i))
+		}
+		if _, ok := roleRank[key.Role]; !ok {
+			problems = append(problems, fmt.Sprintf("auth.apiKeys[%d] has unknown role %q", i, key.Role))
+		}
+		if config.Auth.Enabled && isPlaceholderSecret(key.Key) {
+			problems = append(problems, fmt.Sprintf("auth.apiKeys[%d] still has a placeholder key", i))
+		}
+	}
+	if config.Auth.Enabled && len(config.Auth.APIKeys) == 0 && config.Auth.JWTSecret == "" {
+		problems = append(problems, "auth is enabled without apiKeys or a jwtSecret")
+	}
+	if config.Auth.Enabled && isPlaceholderSecret(config.Auth.JWTSecret) {
+		problems = append(problems, "auth.jwtSecret is still a placeholder")
+	}
+	if config.Limits.RequestsPerSecond < 0 || config.Limits.Burst < 0 || config.Limits.MaxArticlesPerStream < 0 ||
+		config.Limits.MaxStreamBytes < 0 || config.Limits.MaxRecvMsgSize < 0 || config.Limits.MaxSendMsgSize < 0 {
+		problems = append(problems, "limits must not be negative")
+	}
+	if config.Metrics.Enabled && config.Metrics.Addr == "" {
+		problems = append(problems, "metrics.addr is empty")
+	}
+	if config.Gateway.Enabled && config.Gateway.Addr == "" {
+		problems = append(problems, "gateway.addr is empty")
+	}
+	if config.GRPCWeb.Enabled && config.GRPCWeb.Addr == "" {
+		problems = append(problems, "grpcWeb.addr is empty")
+	}
+	if config.Admin.Enabled && config.Admin.Addr == "" {
+		problems = append(problems, "admin.addr is empty")
+	}
+	// without auth anyone reaching these listeners may change the articles
+	for _, listener := range []struct {
+		name    string
+		enabled bool
+		addr    string
+	}{
+		{"gateway.addr", config.Gateway.Enabled, config.Gateway.Addr},
+		{"grpcWeb.addr", config.GRPCWeb.Enabled, config.GRPCWeb.Addr},
+		{"admin.addr", config.Admin.Enabled, config.Admin.Addr},
+	} {
+		if listener.enabled && listener.addr != "" && !config.Auth.Enabled && !isLoopbackAddr(listener.addr) {
+			problems = append(problems, listener.name+" is not a loopback address, enable auth to serve it to other hosts")
+		}
+	}
+	if config.Feeds.Enabled && config.Feeds.Addr == "" {
+		problems = append(problems, "feeds.addr is empty")
+	}
+	if config.Feeds.Items <= 0 {
+		problems = append(problems, "feeds.items must be positive")
+	}
+	if config.Tracing.Enabled && config.Tracing.Exporter != "stdout" && config.Tracing.Exporter != "otlp" {
+		problems = append(problems, fmt.Sprintf("tracing.exporter %q is not stdout or otlp", config.Tracing.Exporter))
+	}
+	for i, sink := range config.LogSinks {
+		if err := sink.validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("logSinks[%d]: %v", i, err))
+		}
+	}
+	if _, err := config.AccessLog.compile(); err != nil {
+		problems = append(problems, "accessLog: "+err.Error())
+	}
+	if len(problems) != 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Check whether a key or secret is still an example value such as reader-key-change-me
+func isPlaceholderSecret(secret string) bool {
+	return strings.HasSuffix(strings.ToLower(secret), "change-me")
+}
+
+// Check whether a listen address only accepts connections from this host
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Get the address of the gRPC listener, only this host can reach it when auth is disabled
+func (config *configuration) grpcAddr() string {
+	if config.Auth.Enabled {
+		return "0.0.0.0:" + config.Port
+	}
+	return "127.0.0.1:" + config.Port
+}
+
+// Names of the changed settings that only take effect after a restart
+func (config *configuration) restartRequired(next *configuration) []string {
+	var changed []string
+	check := func(name string, same bool) {
+		if !same {
+			changed = append(changed, name)
+		}
+	}
+	check("port", config.Port == next.Port)
+	check("accessLogPath", config.AccessLogPath == next.AccessLogPath)
+	check("errorLogPath", config.ErrorLogPath == next.ErrorLogPath)
+	check("logFormat", config.LogFormat == next.LogFormat)
+	check("levelLogPaths", reflect.DeepEqual(config.LevelLogPaths, next.LevelLogPaths))
+	check("storePath", config.StorePath == next.StorePath)
+	check("walPath", config.WALPath == next.WALPath)
+	check("commentsPath", config.CommentsPath == next.CommentsPath)
+	check("limits.maxRecvMsgSize", config.Limits.MaxRecvMsgSize == next.Limits.MaxRecvMsgSize)
+	check("limits.maxSendMsgSize", config.Limits.MaxSendMsgSize == next.Limits.MaxSendMsgSize)
+	check("metrics", config.Metrics == next.Metrics)
+	check("tracing", config.Tracing == next.Tracing)
+	check("gateway", config.Gateway == next.Gateway)
+	check("grpcWeb", reflect.DeepEqual(config.GRPCWeb, next.GRPCWeb))
+	check("admin", config.Admin == next.Admin)
+	check("feeds", config.Feeds == next.Feeds)
+	check("healthCheckInterval", config.HealthCheckInterval == next.HealthCheckInterval)
+	check("publishInterval", config.PublishInterval == next.PublishInterval)
+	check("reflection", config.Reflection == next.Reflection)
+	check("shutdownTimeout", config.ShutdownTimeout == next.ShutdownTimeout)
+	check("store", config.Store == next.Store)
+	check("logSinks", reflect.DeepEqual(config.LogSinks, next.LogSinks))
+	return changed
+}
+
+// Apply the settings that can change while the server is running
+func applyLiveConfig(config *configuration, authn *authenticator, limiter *rateLimiter) {
+	setLogLevel(config.LogLevel)
+	accessPayload.setConfig(config.AccessLog)
+	authn.setConfig(config.Auth)
+	limiter.setConfig(config.Limits)
+}
+
+// Set the minimum level of both web loggers, the level was checked by validate
+func setLogLevel(level string) {
+	minLevel, _ := weblogger.ParseLevel(level)
+	accessWebLogger.SetLevel(minLevel)
+	errorWebLogger.SetLevel(minLevel)
+}
+
+// Send the lines of some levels to their own log files, see conf.json levelLogPaths
+func routeLogLevels(levelLogPaths map[string]string, format string) error {
+	for name, path := range levelLogPaths {
+		level, err := weblogger.ParseLevel(name)
+		if err != nil {
+			return err
+		}
+		sink, err := openFileSink(path, format)
+		if err != nil {
+			return err
+		}
+		errorWebLogger.Route(sink, level)
+	}
+	return nil
+}
+
+// Read conf.json again, apply what can change live and report what needs a restart
+func reloadConfig(current *configuration, authn *authenticator, limiter *rateLimiter) {
+	pc, _, _, _ := runtime.Caller(0)
+
+	next := defaultConfiguration()
+	if err := next.getEnvVariables(); err != nil {
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Config reload rejected, keeping the old config.", err)
+		return
+	}
+
+	restart := current.restartRequired(&next)
+	if len(restart) != 0 {
+		msg := "Config changes need a restart: " + strings.Join(restart, ", ")
+		errorWebLogger.Warn(getCurrentRPCmethod(pc), msg)
+	}
+
+	// keep running with the restart-only settings of the current config
+	current.LogLevel = next.LogLevel
+	current.AccessLog = next.AccessLog
+	current.Auth = next.Auth
+	current.Limits.RequestsPerSecond = next.Limits.RequestsPerSecond
+	current.Limits.Burst = next.Limits.Burst
+	current.Limits.MaxArticlesPerStream = next.Limits.MaxArticlesPerStream
+	current.Limits.MaxStreamBytes = next.Limits.MaxStreamBytes
+	applyLiveConfig(current, authn, limiter)
+	errorWebLogger.Info(getCurrentRPCmethod(pc), "Config reloaded from", confFile)
+}
+
+// Reload conf.json on SIGHUP or when its mtime changes
+func watchConfig(current configuration, authn *authenticator, limiter *rateLimiter) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	var modTime time.Time
+	if info, err := os.Stat(confFile); err == nil {
+		modTime = info.ModTime()
+	}
+	ticker := time.NewTicker(configPollInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hup:
+		case <-ticker.C:
+			info, err := os.Stat(confFile)
+			if err != nil || info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+		}
+		reloadConfig(&current, authn, limiter)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"grpc_web_log/weblogger"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Write conf.json for the test and reload it into current
+func reload(t *testing.T, current *configuration, authn *authenticator, limiter *rateLimiter, conf string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "conf.json")
+	if err := os.WriteFile(path, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useConfFile(t, path, true)
+	reloadConfig(current, authn, limiter)
+}
+
+// Start from the defaults, with the global loggers and access payload reset after the test
+func reloadFixture(t *testing.T) (configuration, *authenticator, *rateLimiter) {
+	t.Helper()
+	t.Cleanup(func() {
+		accessWebLogger.SetLevel(weblogger.LevelDebug)
+		errorWebLogger.SetLevel(weblogger.LevelDebug)
+		accessPayload.setConfig(accessLogConfig{Payload: payloadIDs})
+	})
+	current := defaultConfiguration()
+	return current, newAuthenticator(current.Auth), newRateLimiter(current.Limits)
+}
+
+func TestReloadRejectsInvalidFile(t *testing.T) {
+	for _, conf := range []string{
+		`{"logLevel": "debug",`,
+		`{"logLevel": "loud"}`,
+		`{"logLevel": "debug", "auth": {"enabled": true}}`,
+	} {
+		current, authn, limiter := reloadFixture(t)
+		before := current
+		reload(t, &current, authn, limiter, conf)
+		if !reflect.DeepEqual(current, before) {
+			t.Errorf("%s changed the config to %+v", conf, current)
+		}
+		if authn.currentConfig().Enabled {
+			t.Errorf("%s enabled auth", conf)
+		}
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	current := defaultConfiguration()
+	next := defaultConfiguration()
+	next.Port = "50052"
+	next.StorePath = "other.json"
+	next.Limits.MaxRecvMsgSize = 1 << 10
+	next.Metrics.Enabled = true
+	// live settings are not listed
+	next.LogLevel = "debug"
+	next.Limits.Burst = 5
+	next.Auth.Enabled = true
+
+	want := []string{"port", "storePath", "limits.maxRecvMsgSize", "metrics"}
+	if changed := current.restartRequired(&next); !reflect.DeepEqual(changed, want) {
+		t.Errorf("restartRequired returned %v, want %v", changed, want)
+	}
+	if changed := current.restartRequired(&current); len(changed) != 0 {
+		t.Errorf("restartRequired of the same config returned %v", changed)
+	}
+}
+
+func TestReloadAppliesLiveSettings(t *testing.T) {
+	current, authn, limiter := reloadFixture(t)
+	reload(t, &current, authn, limiter, `{
+		"port": "50052",
+		"logLevel": "warn",
+		"accessLog": {"payload": "hash"},
+		"auth": {"enabled": true, "apiKeys": [{"key": "k", "principal": "p", "role": "admin"}]},
+		"limits": {"requestsPerSecond": 5, "burst": 2, "maxArticlesPerStream": 7, "maxStreamBytes": 100, "maxRecvMsgSize": 1024}
+	}`)
+
+	// the port and the message size need a restart
+	if current.Port != "50051" || current.Limits.MaxRecvMsgSize != 0 {
+		t.Errorf("restart-only settings changed: port %s, maxRecvMsgSize %d", current.Port, current.Limits.MaxRecvMsgSize)
+	}
+	if current.LogLevel != "warn" || errorWebLogger.Enabled(weblogger.LevelInfo) || !errorWebLogger.Enabled(weblogger.LevelWarn) {
+		t.Errorf("log level is %s after reload", current.LogLevel)
+	}
+	if accessPayload.config.Payload != payloadHash {
+		t.Errorf("access log payload is %s, want hash", accessPayload.config.Payload)
+	}
+	if auth := authn.currentConfig(); !auth.Enabled || len(auth.APIKeys) != 1 {
+		t.Errorf("auth is %+v after reload", auth)
+	}
+	want := limitsConfig{RequestsPerSecond: 5, Burst: 2, MaxArticlesPerStream: 7, MaxStreamBytes: 100}
+	if limits := limiter.currentConfig(); limits != want {
+		t.Errorf("limits are %+v, want %+v", limits, want)
+	}
+}
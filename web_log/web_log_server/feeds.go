@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"grpc_web_log/webfeed"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// feedsConfig is the "feeds" section of conf.json
+type feedsConfig struct {
+	Enabled     bool   `json:"enabled"`
+	Addr        string `json:"addr"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// public URL of the web log, articles link to <link>/articles/<articleID>
+	Link   string `json:"link"`
+	Author string `json:"author"`
+	// newest articles in a feed
+	Items int `json:"items"`
+}
+
+// default newest articles in a feed
+const defaultFeedItems = 20
+
+// feed formats
+const (
+	feedRSS  = "rss.xml"
+	feedAtom = "atom.xml"
+)
+
+// feedServer serves the feeds of the article store
+type feedServer struct {
+	config feedsConfig
+}
+
+// Serve the RSS and Atom feeds on the configured address, they are public like a published web log
+func serveFeeds(config feedsConfig) *http.Server {
+	feeds := &feedServer{config: config}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feeds/", feeds.serveFeed)
+	srv := &http.Server{Addr: config.Addr, Handler: mux}
+	pc, _, _, _ := runtime.Caller(0)
+	serveHTTP(srv, getCurrentRPCmethod(pc), "Failed to serve feeds.")
+	return srv
+}
+
+// Serve /feeds/rss.xml, /feeds/atom.xml and the feeds of one tag, /feeds/tags/<tag>/rss.xml and atom.xml
+func (f *feedServer) serveFeed(w http.ResponseWriter, r *http.Request) {
+	ctx, record := startHTTPAccess(r)
+	route := strings.TrimPrefix(r.URL.Path, "/feeds/")
+	parts := strings.Split(route, "/")
+	var tag, format string
+	var err error
+	switch {
+	case r.Method != http.MethodGet && r.Method != http.MethodHead:
+		err = status.Error(codes.Unimplemented, "feeds only answer GET")
+	case len(parts) == 1:
+		format = parts[0]
+	case len(parts) == 3 && parts[0] == "tags" && parts[1] != "":
+		tag, format = parts[1], parts[2]
+	}
+	if err == nil && format != feedRSS && format != feedAtom {
+		err = status.Error(codes.NotFound, "no such feed")
+	}
+	if tag != "" {
+		setAccessPayload(ctx, "tag="+tag)
+	}
+	record.write(ctx, "/feeds/"+route, err)
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), gwruntime.HTTPStatusFromCode(status.Code(err)))
+		return
+	}
+
+	// a removed or unpublished article changes the feed too, so the store's time is the feed's
+	updated := store.modified(ctx)
+	feed := f.feed(f.newest(publishedArticles(store.all(ctx)), tag, updated), tag, updated)
+	var body []byte
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == feedRSS {
+		body, err = feed.RSS()
+	} else {
+		body, err = feed.Atom()
+		contentType = "application/atom+xml; charset=utf-8"
+	}
+	if err != nil {
+		pc, _, _, _ := runtime.Caller(0)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Failed to write feed.", err)
+		http.Error(w, "feed could not be written", http.StatusInternalServerError)
+		return
+	}
+
+	// ServeContent answers If-None-Match and If-Modified-Since with 304 Not Modified
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	http.ServeContent(w, r, "", updated, bytes.NewReader(body))
+}
+
+// Get the newest articles with the tag, all articles when tag is empty, by creation time so an edit does not move an
+// article to the top. Articles saved before timestamps were stored are dated fallback.
+func (f *feedServer) newest(articles Articles, tag string, fallback time.Time) Articles {
+	var tagged Articles
+	for _, article := range articles {
+		if tag == "" || hasTag(article, tag) {
+			if article.Created.IsZero() {
+				article.Created = fallback
+			}
+			if article.Updated.IsZero() {
+				article.Updated = fallback
+			}
+			tagged = append(tagged, article)
+		}
+	}
+	sort.SliceStable(tagged, func(i, j int) bool {
+		return tagged[i].Created.After(tagged[j].Created)
+	})
+	if len(tagged) > f.config.Items {
+		tagged = tagged[:f.config.Items]
+	}
+	return tagged
+}
+
+// Get the title of a feed
+func (f *feedServer) title(tag string) string {
+	if tag == "" {
+		return f.config.Title
+	}
+	return f.config.Title + " - " + tag
+}
+
+// Get the excerpt of an article, its title when it cannot be rendered
+func articleExcerpt(article Article) string {
+	rendered, err := renders.get(article)
+	if err != nil {
+		return article.Title
+	}
+	return rendered.Excerpt
+}
+
+// Build the feed of the articles
+func (f *feedServer) feed(articles Articles, tag string, updated time.Time) *webfeed.Feed {
+	link := strings.TrimSuffix(f.config.Link, "/")
+	self := link + "/feeds/" + feedAtom
+	if tag != "" {
+		self = link + "/feeds/tags/" + url.PathEscape(tag) + "/" + feedAtom
+	}
+	feed := &webfeed.Feed{
+		Title:       f.title(tag),
+		Description: f.config.Description,
+		Link:        f.config.Link,
+		Self:        self,
+		Author:      f.config.Author,
+		Updated:     updated,
+	}
+	for _, article := range articles {
+		feed.Items = append(feed.Items, webfeed.Item{
+			ID:        "urn:uuid:" + article.ArticleID,
+			Title:     article.Title,
+			Link:      link + "/articles/" + article.ArticleID,
+			Summary:   articleExcerpt(article),
+			Published: article.Created,
+			Updated:   article.Updated,
+			Tags:      article.Tags,
+		})
+	}
+	return feed
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFeedOrdersByCreation(t *testing.T) {
+	f := &feedServer{config: feedsConfig{Items: 10}}
+	now := time.Now()
+	articles := f.newest(Articles{
+		{ArticleID: "old", Created: now.Add(-time.Hour), Updated: now},
+		{ArticleID: "new", Created: now.Add(-time.Minute), Updated: now.Add(-time.Minute)},
+	}, "", now)
+	if len(articles) != 2 || articles[0].ArticleID != "new" {
+		t.Errorf("feed lists %+v, want the new article first although the old one was edited later", articles)
+	}
+}
+
+func TestFeedLastModifiedChangesOnRemove(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "a"}, Article{ArticleID: "b", Title: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	f := &feedServer{config: feedsConfig{Items: 10}}
+	get := func() *http.Response {
+		rec := httptest.NewRecorder()
+		f.serveFeed(rec, httptest.NewRequest(http.MethodGet, "/feeds/atom.xml", nil))
+		return rec.Result()
+	}
+	before := get().Header.Get("Last-Modified")
+
+	// Last-Modified has a resolution of a second
+	time.Sleep(time.Second)
+	if _, err := store.remove(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if after := get().Header.Get("Last-Modified"); after == before {
+		t.Errorf("Last-Modified stayed %s after a removal", after)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/webgateway"
+	"net/http"
+	"runtime"
+)
+
+// gatewayConfig is the "gateway" section of conf.json
+type gatewayConfig struct {
+	Enabled bool   `json:"enabled"`
+	Addr    string `json:"addr"`
+}
+
+// Serve the REST/JSON gateway in-process, it calls the gRPC server on its port so every interceptor applies.
+// Returns nil when the gateway cannot start.
+func serveGateway(config gatewayConfig, port string) *http.Server {
+	pc, _, _, _ := runtime.Caller(0)
+	handler, err := webgateway.New(context.Background(), "127.0.0.1:"+port)
+	if err != nil {
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Failed to start REST gateway.", err)
+		return nil
+	}
+	srv := &http.Server{Addr: config.Addr, Handler: handler}
+	serveHTTP(srv, getCurrentRPCmethod(pc), "Failed to serve REST gateway.")
+	return srv
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// grpcWebConfig is the "grpcWeb" section of conf.json
+type grpcWebConfig struct {
+	Enabled bool   `json:"enabled"`
+	Addr    string `json:"addr"`
+	// origins allowed by CORS, "*" allows every origin
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// request headers allowed by CORS besides the gRPC-Web ones
+	AllowedHeaders []string `json:"allowedHeaders"`
+}
+
+// request headers sent by gRPC-Web browser clients
+var grpcWebHeaders = []string{"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout"}
+
+// Check an Origin header against allowedOrigins
+func (config grpcWebConfig) allowOrigin(origin string) bool {
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the gRPC-Web handler (binary and text encoding, HTTP/1.1 or HTTP/2) of the services of s,
+// the calls go through the same interceptors as gRPC calls
+func grpcWebHandler(s *grpc.Server, config grpcWebConfig) http.Handler {
+	wrapped := grpcweb.WrapServer(s,
+		grpcweb.WithOriginFunc(config.allowOrigin),
+		grpcweb.WithAllowedRequestHeaders(append(grpcWebHeaders, config.AllowedHeaders...)),
+		grpcweb.WithCorsForRegisteredEndpointsOnly(true),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wrapped.IsGrpcWebRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// Serve gRPC-Web for the services of s
+func serveGRPCWeb(s *grpc.Server, config grpcWebConfig) *http.Server {
+	srv := &http.Server{Addr: config.Addr, Handler: grpcWebHandler(s, config)}
+	pc, _, _, _ := runtime.Caller(0)
+	serveHTTP(srv, getCurrentRPCmethod(pc), "Failed to serve gRPC-Web.")
+	return srv
+}
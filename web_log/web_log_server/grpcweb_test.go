@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"grpc_web_log/web_log/web_log_pb"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// origin allowed by the test gRPC-Web listener
+const testOrigin = "https://weblog.example"
+
+// bearer token of the test editor
+const testEditorKey = "test-editor-key"
+
+// Serve the services through the gRPC-Web handler, with the interceptors of main
+func newGRPCWebTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	dir := t.TempDir()
+	st, err := openArticleStore(context.Background(), filepath.Join(dir, "saveArticles.json"), filepath.Join(dir, "saveArticles.wal"), storeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store = st
+	t.Cleanup(func() { st.wal.close() })
+
+	authn := newAuthenticator(authConfig{Enabled: true, APIKeys: []apiKey{{Key: testEditorKey, Principal: "editor", Role: roleEditor}}})
+	limiter := newRateLimiter(limitsConfig{})
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, accessUnaryInterceptor, authn.unaryInterceptor, limiter.unaryInterceptor),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor, accessStreamInterceptor, authn.streamInterceptor, limiter.streamInterceptor),
+	)
+	web_log_pb.RegisterWebLogServiceServer(s, &server{})
+	web_log_pb.RegisterCommentServiceServer(s, &commentServer{})
+	t.Cleanup(s.Stop)
+
+	ts := httptest.NewServer(grpcWebHandler(s, grpcWebConfig{AllowedOrigins: []string{testOrigin}, AllowedHeaders: []string{"authorization"}}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// grpcWebResponse is the messages and status of a gRPC-Web call
+type grpcWebResponse struct {
+	messages [][]byte
+	status   *status.Status
+}
+
+// Call a method of the WebLogService with a gRPC-Web client, in the text encoding of browsers or the binary one
+func grpcWebCall(ctx context.Context, baseURL string, token string, method string, req proto.Message, text bool) (*http.Response, error) {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	frame := make([]byte, 5+len(body))
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(body)))
+	copy(frame[5:], body)
+	contentType := "application/grpc-web+proto"
+	if text {
+		contentType = "application/grpc-web-text"
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/web_log.WebLogService/"+method, bytes.NewReader(frame))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept", contentType)
+	httpReq.Header.Set("X-Grpc-Web", "1")
+	httpReq.Header.Set("Origin", testOrigin)
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	return http.DefaultClient.Do(httpReq)
+}
+
+// Read the next frame of a binary gRPC-Web body
+func readGRPCWebFrame(r io.Reader) (flag byte, payload []byte, err error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	payload = make([]byte, binary.BigEndian.Uint32(header[1:5]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+// Read a whole gRPC-Web response, the status is in the trailer frame or, trailers-only, in the headers
+func readGRPCWebResponse(httpRes *http.Response, text bool) (*grpcWebResponse, error) {
+	defer httpRes.Body.Close()
+	var body io.Reader = httpRes.Body
+	if text {
+		encoded, err := io.ReadAll(httpRes.Body)
+		if err != nil {
+			return nil, err
+		}
+		// each frame is padded base64 of its own
+		var data []byte
+		for s := string(encoded); len(s) >= 4; s = s[4:] {
+			chunk, err := base64.StdEncoding.DecodeString(s[:4])
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, chunk...)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	trailers := http.Header{}
+	for key, values := range httpRes.Header {
+		trailers[key] = values
+	}
+	res := &grpcWebResponse{}
+	for {
+		flag, payload, err := readGRPCWebFrame(body)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if flag&0x80 == 0 {
+			res.messages = append(res.messages, payload)
+			continue
+		}
+		for _, line := range strings.Split(string(payload), "\r\n") {
+			if i := strings.IndexByte(line, ':'); i > 0 {
+				trailers.Set(strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]))
+			}
+		}
+	}
+	code, err := strconv.Atoi(trailers.Get("Grpc-Status"))
+	if err != nil {
+		return nil, errors.New("gRPC-Web response has no grpc-status")
+	}
+	res.status = status.New(codes.Code(code), trailers.Get("Grpc-Message"))
+	return res, nil
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	ts := newGRPCWebTestServer(t)
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "grpc-web title", Content: "content"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []bool{true, false} {
+		httpRes, err := grpcWebCall(ctx, ts.URL, testEditorKey, "GetSpecifiedArticle", &web_log_pb.GetSpecifiedArticleRequest{ArticleID: "a"}, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := httpRes.Header.Get("Access-Control-Allow-Origin"); got != testOrigin {
+			t.Errorf("Access-Control-Allow-Origin is %q, want %q", got, testOrigin)
+		}
+		res, err := readGRPCWebResponse(httpRes, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.status.Code() != codes.OK || len(res.messages) != 1 {
+			t.Fatalf("text=%v: status %v with %d messages", text, res.status, len(res.messages))
+		}
+		article := &web_log_pb.GetSpecifiedArticleResponse{}
+		if err := proto.Unmarshal(res.messages[0], article); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(article.String(), "grpc-web title") {
+			t.Errorf("text=%v: got %v", text, article)
+		}
+	}
+
+	// an error status comes back in the trailers
+	httpRes, err := grpcWebCall(ctx, ts.URL, "wrong-key", "GetSpecifiedArticle", &web_log_pb.GetSpecifiedArticleRequest{ArticleID: "a"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := readGRPCWebResponse(httpRes, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.status.Code() != codes.Unauthenticated {
+		t.Errorf("wrong key returned %v, want Unauthenticated", res.status)
+	}
+}
+
+func TestGRPCWebServerStreaming(t *testing.T) {
+	ts := newGRPCWebTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// resume from before the two saves, so both events are replayed
+	watchers.mu.Lock()
+	cursor := watchers.run + "-" + strconv.FormatUint(watchers.seq, 10)
+	watchers.mu.Unlock()
+	if err := store.add(ctx, Article{ArticleID: "a"}, Article{ArticleID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	httpRes, err := grpcWebCall(ctx, ts.URL, testEditorKey, "WatchArticles", &web_log_pb.WatchArticlesRequest{Cursor: cursor}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpRes.Body.Close()
+	for _, want := range []string{"a", "b"} {
+		flag, payload, err := readGRPCWebFrame(httpRes.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if flag&0x80 != 0 {
+			t.Fatalf("stream ended before the event of %s: %q", want, payload)
+		}
+		event := &web_log_pb.ArticleEvent{}
+		if err := proto.Unmarshal(payload, event); err != nil {
+			t.Fatal(err)
+		}
+		if event.GetArticleID() != want || event.GetType() != web_log_pb.ArticleEventType_CREATED {
+			t.Errorf("got event %v, want CREATED %s", event, want)
+		}
+	}
+}
+
+// Send a CORS preflight for GetAllArticles from the origin
+func preflight(t *testing.T, baseURL string, origin string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest("OPTIONS", baseURL+"/web_log.WebLogService/GetAllArticles", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	return res
+}
+
+func TestGRPCWebPreflight(t *testing.T) {
+	ts := newGRPCWebTestServer(t)
+
+	res := preflight(t, ts.URL, testOrigin)
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != testOrigin {
+		t.Errorf("allowed origin: Access-Control-Allow-Origin is %q, want %q", got, testOrigin)
+	}
+	if got := strings.ToLower(res.Header.Get("Access-Control-Allow-Headers")); !strings.Contains(got, "x-grpc-web") {
+		t.Errorf("allowed origin: Access-Control-Allow-Headers is %q", got)
+	}
+
+	res = preflight(t, ts.URL, "https://evil.example")
+	if got := res.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("rejected origin: Access-Control-Allow-Origin is %q, want none", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"runtime"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// service name reported by the health server, "" is the overall server health
+const webLogServiceName = "web_log.WebLogService"
+
+// default seconds between two article store checks
+const defaultHealthCheckInterval = 5
+
+// Check whether the saved articles json file can be read and decoded
+func checkStore(jsonFilePath string) error {
+	jsonData, err := ioutil.ReadFile(jsonFilePath)
+	if err != nil {
+		return err
+	}
+	var articles Articles
+	return json.Unmarshal(jsonData, &articles)
+}
+
+// Report SERVING or NOT_SERVING depending on the article store, every interval seconds
+func watchStoreHealth(healthServer *health.Server, jsonFilePath string, interval int) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	var lastErr error
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		err := checkStore(jsonFilePath)
+		if err != nil {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+			// only log when the store becomes unreadable
+			if lastErr == nil {
+				pc, _, _, _ := runtime.Caller(0)
+				errorWebLogger.Error(getCurrentRPCmethod(pc), "Article store is unreadable.", err)
+			}
+		}
+		lastErr = err
+
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(webLogServiceName, servingStatus)
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"grpc_web_log/weblogger"
+	"os"
+)
+
+// log line formats
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// Open a log file sink in the format
+func openFileSink(filePath string, format string) (weblogger.Sink, error) {
+	if format == logFormatJSON {
+		return weblogger.NewJSONFileSink(filePath)
+	}
+	return weblogger.NewFileSink(filePath)
+}
+
+// Open access.log and error.log in the configured format
+func initLogFiles(config configuration) {
+	if config.LogFormat != logFormatJSON {
+		accessWebLogger.InitWebLogger(config.AccessLogPath)
+		errorWebLogger.InitWebLogger(config.ErrorLogPath)
+		return
+	}
+	for _, log := range []struct {
+		logger *weblogger.Weblogger
+		path   string
+	}{{&accessWebLogger, config.AccessLogPath}, {&errorWebLogger, config.ErrorLogPath}} {
+		sink, err := openFileSink(log.path, config.LogFormat)
+		if err != nil {
+			errorWebLogger.ServerFatalPrintln("File open error", err)
+		}
+		log.logger.AddDefaultSink(sink)
+	}
+}
+
+// logSinkConfig is one entry of the "logSinks" list of conf.json
+type logSinkConfig struct {
+	// stdout or syslog
+	Type string `json:"type"`
+	// syslog: unixgram or unix for a local socket such as /dev/log, tcp or udp for a collector
+	Network string `json:"network,omitempty"`
+	Addr    string `json:"addr,omitempty"`
+	AppName string `json:"appName,omitempty"`
+	// access and/or error, both when empty
+	Logs []string `json:"logs,omitempty"`
+	// records buffered in front of the sink, 0 writes directly
+	BufferSize int `json:"bufferSize,omitempty"`
+	// drop (the default) or block when the buffer is full
+	Backpressure string `json:"backpressure,omitempty"`
+}
+
+// Check a log sink entry
+func (config logSinkConfig) validate() error {
+	switch config.Type {
+	case "stdout":
+	case "syslog":
+		switch config.Network {
+		case "unixgram", "unix", "tcp", "udp":
+		default:
+			return fmt.Errorf("network %q is not unixgram, unix, tcp or udp", config.Network)
+		}
+		if config.Addr == "" {
+			return fmt.Errorf("addr is empty")
+		}
+	default:
+		return fmt.Errorf("type %q is not stdout or syslog", config.Type)
+	}
+	for _, log := range config.Logs {
+		if log != "access" && log != "error" {
+			return fmt.Errorf("logs has unknown log %q", log)
+		}
+	}
+	if config.BufferSize < 0 {
+		return fmt.Errorf("bufferSize must not be negative")
+	}
+	if _, err := weblogger.ParseBackpressure(config.Backpressure); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Open the sink of an entry, wrapped in a buffer if bufferSize is set
+func (config logSinkConfig) open(log string, format string) (weblogger.Sink, error) {
+	var sink weblogger.Sink
+	switch config.Type {
+	case "stdout":
+		sink = weblogger.NewWriterSink(os.Stdout)
+		if format == logFormatJSON {
+			sink = weblogger.NewJSONWriterSink(os.Stdout)
+		}
+	case "syslog":
+		syslog, err := weblogger.NewSyslogSink(config.Network, config.Addr, config.AppName)
+		if err != nil {
+			return nil, err
+		}
+		sink = syslog
+	default:
+		return nil, fmt.Errorf("unknown log sink type %q", config.Type)
+	}
+	if config.BufferSize == 0 {
+		return sink, nil
+	}
+	backpressure, err := weblogger.ParseBackpressure(config.Backpressure)
+	if err != nil {
+		return nil, err
+	}
+	buffered := weblogger.NewBufferedSink(sink, config.BufferSize, backpressure)
+	buffered.OnError = countLogWriteFailure(log)
+	return buffered, nil
+}
+
+// Add the configured sinks to the access and error web loggers
+func addLogSinks(sinks []logSinkConfig, format string) error {
+	loggers := map[string]*weblogger.Weblogger{
+		"access": &accessWebLogger,
+		"error":  &errorWebLogger,
+	}
+	for _, config := range sinks {
+		logs := config.Logs
+		if len(logs) == 0 {
+			logs = []string{"access", "error"}
+		}
+		for _, log := range logs {
+			sink, err := config.open(log, format)
+			if err != nil {
+				return err
+			}
+			loggers[log].AddSink(sink)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"grpc_web_log/weblogger"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRoutedLinesReachLogSinks(t *testing.T) {
+	// the fake collector reads octet-counted syslog messages
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	received := make(chan string, 16)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	debugLog := filepath.Join(t.TempDir(), "debug.log")
+	defer errorWebLogger.Close()
+	errorWebLogger.SetLevel(weblogger.LevelDebug)
+	err = addLogSinks([]logSinkConfig{{Type: "syslog", Network: "tcp", Addr: ln.Addr().String(), Logs: []string{"error"}, BufferSize: 10}}, logFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := routeLogLevels(map[string]string{"debug": debugLog}, logFormatText); err != nil {
+		t.Fatal(err)
+	}
+
+	errorWebLogger.Debug("TestRoutedLinesReachLogSinks", "routed debug line")
+	select {
+	case msg := <-received:
+		if !strings.HasSuffix(msg, "routed debug line") {
+			t.Errorf("collector received %q", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("routed line did not reach the syslog sink")
+	}
+	data, err := os.ReadFile(debugLog)
+	if err != nil || !strings.Contains(string(data), "routed debug line") {
+		t.Errorf("debug.log has %q, %v", data, err)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsConfig is the "metrics" section of conf.json
+type metricsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Addr    string `json:"addr"`
+}
+
+// prometheus metrics of the web log server
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weblog_grpc_requests_total",
+		Help: "WebLogService requests by method and gRPC status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "weblog_grpc_request_duration_seconds",
+		Help:    "WebLogService request latency by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	rpcInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "weblog_grpc_requests_in_flight",
+		Help: "WebLogService requests currently being handled.",
+	}, []string{"method"})
+
+	articlesStored = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "weblog_articles_stored",
+		Help: "Articles in the article store.",
+	})
+
+	storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "weblog_store_operation_duration_seconds",
+		Help:    "Article store read and write durations.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	logWriteFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weblog_log_write_failures_total",
+		Help: "Lines that could not be written to access.log or error.log.",
+	}, []string{"log"})
+
+	renderCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "weblog_render_cache_lookups_total",
+		Help: "RenderArticle cache lookups by result, hit or miss.",
+	}, []string{"result"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		rpcRequests,
+		rpcDuration,
+		rpcInFlight,
+		articlesStored,
+		storeDuration,
+		logWriteFailures,
+		renderCacheLookups,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Record how long a store operation took, use as defer observeStore("read")()
+func observeStore(operation string) func() {
+	start := time.Now()
+	return func() {
+		storeDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Count a failed log write for the named log file
+func countLogWriteFailure(log string) func(error) {
+	return func(error) {
+		logWriteFailures.WithLabelValues(log).Inc()
+	}
+}
+
+// Record the request count, latency and in-flight gauge of one RPC
+func observeRPC(fullMethod string, call func() error) error {
+	inFlight := rpcInFlight.WithLabelValues(fullMethod)
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	start := time.Now()
+	err := call()
+	rpcDuration.WithLabelValues(fullMethod).Observe(time.Since(start).Seconds())
+	rpcRequests.WithLabelValues(fullMethod, status.Code(err).String()).Inc()
+	return err
+}
+
+// metricsUnaryInterceptor records metrics for unary RPCs
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var res interface{}
+	err := observeRPC(info.FullMethod, func() error {
+		var err error
+		res, err = handler(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+// metricsStreamInterceptor records metrics for streaming RPCs
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return observeRPC(info.FullMethod, func() error {
+		return handler(srv, ss)
+	})
+}
+
+// Serve /metrics on the configured address
+func serveMetrics(config metricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: config.Addr, Handler: mux}
+	pc, _, _, _ := runtime.Caller(0)
+	serveHTTP(srv, getCurrentRPCmethod(pc), "Failed to serve metrics.")
+	return srv
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Settings are resolved in this order, a later source overrides an earlier one:
+//  1. defaults (defaultConfiguration)
+//  2. conf.json (-config or WEBLOG_CONFIG picks the file)
+//  3. environment variables, WEBLOG_ followed by the flag name in upper case with _ for -
+//  4. command-line flags
+
+// setting is a configuration field that can be overridden by a flag and an environment variable
+type setting struct {
+	name   string // flag name
+	usage  string
+	isBool bool
+	set    func(config *configuration, value string) error
+}
+
+// Environment variable of a setting, e.g. store-path -> WEBLOG_STORE_PATH
+func (s setting) env() string {
+	return "WEBLOG_" + strings.ToUpper(strings.Replace(s.name, "-", "_", -1))
+}
+
+func stringSetting(name string, usage string, field func(*configuration) *string) setting {
+	return setting{name: name, usage: usage, set: func(config *configuration, value string) error {
+		*field(config) = value
+		return nil
+	}}
+}
+
+func intSetting(name string, usage string, field func(*configuration) *int) setting {
+	return setting{name: name, usage: usage, set: func(config *configuration, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(config) = n
+		return nil
+	}}
+}
+
+func int64Setting(name string, usage string, field func(*configuration) *int64) setting {
+	return setting{name: name, usage: usage, set: func(config *configuration, value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field(config) = n
+		return nil
+	}}
+}
+
+func floatSetting(name string, usage string, field func(*configuration) *float64) setting {
+	return setting{name: name, usage: usage, set: func(config *configuration, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field(config) = f
+		return nil
+	}}
+}
+
+func boolSetting(name string, usage string, field func(*configuration) *bool) setting {
+	return setting{name: name, usage: usage, isBool: true, set: func(config *configuration, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(config) = b
+		return nil
+	}}
+}
+
+// every setting of conf.json
+var settings = []setting{
+	stringSetting("port", "gRPC listen port", func(c *configuration) *string { return &c.Port }),
+	stringSetting("log-level", "debug, info, warn, error or fatal", func(c *configuration) *string { return &c.LogLevel }),
+	stringSetting("log-format", "text or json log lines", func(c *configuration) *string { return &c.LogFormat }),
+	stringSetting("access-log-path", "access log file", func(c *configuration) *string { return &c.AccessLogPath }),
+	stringSetting("error-log-path", "error log file", func(c *configuration) *string { return &c.ErrorLogPath }),
+	{name: "level-log-paths", usage: `log file per level as JSON, e.g. {"debug":"logger/debug.log"}`,
+		set: func(config *configuration, value string) error {
+			var paths map[string]string
+			if err := json.Unmarshal([]byte(value), &paths); err != nil {
+				return err
+			}
+			config.LevelLogPaths = paths
+			return nil
+		}},
+	{name: "log-sinks", usage: `extra log outputs as JSON, e.g. [{"type":"stdout"}]`,
+		set: func(config *configuration, value string) error {
+			var sinks []logSinkConfig
+			if err := json.Unmarshal([]byte(value), &sinks); err != nil {
+				return err
+			}
+			config.LogSinks = sinks
+			return nil
+		}},
+	stringSetting("access-log-payload", "article fields in access.log: ids, truncate, hash or full", func(c *configuration) *string { return &c.AccessLog.Payload }),
+	intSetting("access-log-truncate-bytes", "content bytes kept by the truncate payload", func(c *configuration) *int { return &c.AccessLog.TruncateBytes }),
+	{name: "access-log-redact", usage: `patterns redacted from access.log as JSON, e.g. ["(?i)secret"]`,
+		set: func(config *configuration, value string) error {
+			var patterns []string
+			if err := json.Unmarshal([]byte(value), &patterns); err != nil {
+				return err
+			}
+			config.AccessLog.Redact = patterns
+			return nil
+		}},
+	stringSetting("store-path", "saved articles json file", func(c *configuration) *string { return &c.StorePath }),
+	stringSetting("wal-path", "article store write-ahead log", func(c *configuration) *string { return &c.WALPath }),
+	stringSetting("comments-path", "comments json file", func(c *configuration) *string { return &c.CommentsPath }),
+	boolSetting("auth-enabled", "require bearer tokens", func(c *configuration) *bool { return &c.Auth.Enabled }),
+	{name: "auth-api-keys", usage: `API keys as JSON, e.g. [{"key":"k","principal":"p","role":"reader"}]`,
+		set: func(config *configuration, value string) error {
+			var keys []apiKey
+			if err := json.Unmarshal([]byte(value), &keys); err != nil {
+				return err
+			}
+			config.Auth.APIKeys = keys
+			return nil
+		}},
+	stringSetting("auth-jwt-secret", "HMAC secret of the JWTs", func(c *configuration) *string { return &c.Auth.JWTSecret }),
+	floatSetting("limits-requests-per-second", "token bucket rate per client", func(c *configuration) *float64 { return &c.Limits.RequestsPerSecond }),
+	intSetting("limits-burst", "token bucket size per client", func(c *configuration) *int { return &c.Limits.Burst }),
+	intSetting("limits-max-articles-per-stream", "articles per SaveAllArticles stream", func(c *configuration) *int { return &c.Limits.MaxArticlesPerStream }),
+	intSetting("limits-max-stream-bytes", "bytes per SaveAllArticles stream", func(c *configuration) *int { return &c.Limits.MaxStreamBytes }),
+	intSetting("limits-max-recv-msg-size", "max received message bytes", func(c *configuration) *int { return &c.Limits.MaxRecvMsgSize }),
+	intSetting("limits-max-send-msg-size", "max sent message bytes", func(c *configuration) *int { return &c.Limits.MaxSendMsgSize }),
+	boolSetting("metrics-enabled", "serve Prometheus metrics", func(c *configuration) *bool { return &c.Metrics.Enabled }),
+	stringSetting("metrics-addr", "metrics listen address", func(c *configuration) *string { return &c.Metrics.Addr }),
+	boolSetting("gateway-enabled", "serve the REST/JSON gateway", func(c *configuration) *bool { return &c.Gateway.Enabled }),
+	stringSetting("gateway-addr", "REST/JSON gateway listen address", func(c *configuration) *string { return &c.Gateway.Addr }),
+	boolSetting("grpc-web-enabled", "serve gRPC-Web", func(c *configuration) *bool { return &c.GRPCWeb.Enabled }),
+	stringSetting("grpc-web-addr", "gRPC-Web listen address", func(c *configuration) *string { return &c.GRPCWeb.Addr }),
+	{name: "grpc-web-allowed-origins", usage: `CORS origins as JSON, e.g. ["https://admin.example.com"]`,
+		set: func(config *configuration, value string) error {
+			var origins []string
+			if err := json.Unmarshal([]byte(value), &origins); err != nil {
+				return err
+			}
+			config.GRPCWeb.AllowedOrigins = origins
+			return nil
+		}},
+	{name: "grpc-web-allowed-headers", usage: `CORS request headers as JSON, e.g. ["authorization"]`,
+		set: func(config *configuration, value string) error {
+			var headers []string
+			if err := json.Unmarshal([]byte(value), &headers); err != nil {
+				return err
+			}
+			config.GRPCWeb.AllowedHeaders = headers
+			return nil
+		}},
+	boolSetting("admin-enabled", "serve the admin UI", func(c *configuration) *bool { return &c.Admin.Enabled }),
+	stringSetting("admin-addr", "admin UI listen address", func(c *configuration) *string { return &c.Admin.Addr }),
+	boolSetting("feeds-enabled", "serve RSS and Atom feeds", func(c *configuration) *bool { return &c.Feeds.Enabled }),
+	stringSetting("feeds-addr", "feeds listen address", func(c *configuration) *string { return &c.Feeds.Addr }),
+	stringSetting("feeds-title", "title of the feeds", func(c *configuration) *string { return &c.Feeds.Title }),
+	stringSetting("feeds-description", "description of the feeds", func(c *configuration) *string { return &c.Feeds.Description }),
+	stringSetting("feeds-link", "public URL of the web log", func(c *configuration) *string { return &c.Feeds.Link }),
+	stringSetting("feeds-author", "author of the feed entries", func(c *configuration) *string { return &c.Feeds.Author }),
+	intSetting("feeds-items", "newest articles in a feed", func(c *configuration) *int { return &c.Feeds.Items }),
+	boolSetting("tracing-enabled", "export OpenTelemetry spans", func(c *configuration) *bool { return &c.Tracing.Enabled }),
+	stringSetting("tracing-exporter", "stdout or otlp", func(c *configuration) *string { return &c.Tracing.Exporter }),
+	stringSetting("tracing-endpoint", "otlp collector host:port", func(c *configuration) *string { return &c.Tracing.Endpoint }),
+	boolSetting("tracing-insecure", "otlp without TLS", func(c *configuration) *bool { return &c.Tracing.Insecure }),
+	floatSetting("tracing-sample-ratio", "share of traces sampled", func(c *configuration) *float64 { return &c.Tracing.SampleRatio }),
+	intSetting("health-check-interval", "seconds between store health checks", func(c *configuration) *int { return &c.HealthCheckInterval }),
+	intSetting("publish-interval", "seconds between checks for scheduled articles", func(c *configuration) *int { return &c.PublishInterval }),
+	boolSetting("reflection", "enable server reflection", func(c *configuration) *bool { return &c.Reflection }),
+	intSetting("shutdown-timeout", "seconds to drain RPCs on shutdown", func(c *configuration) *int { return &c.ShutdownTimeout }),
+	intSetting("store-compact-interval", "seconds between write-ahead log compactions", func(c *configuration) *int { return &c.Store.CompactInterval }),
+	int64Setting("store-compact-bytes", "write-ahead log size that triggers a compaction", func(c *configuration) *int64 { return &c.Store.CompactBytes }),
+}
+
+// flagValue keeps the raw value of a flag, it is applied on every (re)load of conf.json
+type flagValue struct {
+	isBool bool
+	value  string
+}
+
+func (f *flagValue) String() string     { return f.value }
+func (f *flagValue) Set(v string) error { f.value = v; return nil }
+func (f *flagValue) IsBoolFlag() bool   { return f.isBool }
+
+// command-line flags
+var (
+	flagValues  = make(map[string]*flagValue)
+	setFlags    = make(map[string]bool)
+	printConfig = flag.Bool("print-config", false, "print the resolved configuration and exit")
+)
+
+func init() {
+	flag.StringVar(&confFile, "config", confFile, "config file, also WEBLOG_CONFIG")
+	for _, s := range settings {
+		f := &flagValue{isBool: s.isBool}
+		flagValues[s.name] = f
+		flag.Var(f, s.name, s.usage+", also "+s.env())
+	}
+}
+
+// Parse the command line, WEBLOG_CONFIG is used unless -config is given
+func parseFlags() {
+	if env, ok := os.LookupEnv("WEBLOG_CONFIG"); ok {
+		confFile = env
+		confFileGiven = true
+	}
+	flag.Parse()
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+		if f.Name == "config" {
+			confFileGiven = true
+		}
+	})
+}
+
+// Apply environment variables, then flags, on top of conf.json
+func (config *configuration) applyOverrides() error {
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env()); ok {
+			if err := s.set(config, value); err != nil {
+				return fmt.Errorf("%s: %v", s.env(), err)
+			}
+		}
+	}
+	for _, s := range settings {
+		if setFlags[s.name] {
+			if err := s.set(config, flagValues[s.name].value); err != nil {
+				return fmt.Errorf("-%s: %v", s.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Print the resolved configuration as json, secrets are masked
+func (config configuration) print() {
+	const masked = "******"
+	if config.Auth.JWTSecret != "" {
+		config.Auth.JWTSecret = masked
+	}
+	keys := make([]apiKey, len(config.Auth.APIKeys))
+	for i, key := range config.Auth.APIKeys {
+		key.Key = masked
+		keys[i] = key
+	}
+	config.Auth.APIKeys = keys
+
+	fmt.Println("config file:", confFile)
+	out, _ := json.MarshalIndent(config, "", "    ")
+	fmt.Println(string(out))
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Set a command-line flag for the test
+func setFlag(t *testing.T, name string, value string) {
+	t.Helper()
+	flagValues[name].value = value
+	setFlags[name] = true
+	t.Cleanup(func() {
+		flagValues[name].value = ""
+		delete(setFlags, name)
+	})
+}
+
+// Use a config file for the test
+func useConfFile(t *testing.T, path string, given bool) {
+	t.Helper()
+	oldFile, oldGiven := confFile, confFileGiven
+	confFile, confFileGiven = path, given
+	t.Cleanup(func() {
+		confFile, confFileGiven = oldFile, oldGiven
+	})
+}
+
+func TestApplyOverridesPrecedence(t *testing.T) {
+	config := defaultConfiguration()
+	config.Port = "50000"
+	config.LogLevel = "warn"
+	config.StorePath = "file.json"
+
+	t.Setenv("WEBLOG_PORT", "50001")
+	t.Setenv("WEBLOG_LOG_LEVEL", "debug")
+	setFlag(t, "port", "50002")
+	if err := config.applyOverrides(); err != nil {
+		t.Fatal(err)
+	}
+	// a flag beats the environment, which beats conf.json
+	if config.Port != "50002" || config.LogLevel != "debug" || config.StorePath != "file.json" {
+		t.Errorf("port %s, logLevel %s, storePath %s, want 50002, debug, file.json", config.Port, config.LogLevel, config.StorePath)
+	}
+}
+
+func TestApplyOverridesNamesTheBadValue(t *testing.T) {
+	config := defaultConfiguration()
+	t.Setenv("WEBLOG_LIMITS_BURST", "many")
+	if err := config.applyOverrides(); err == nil || !strings.HasPrefix(err.Error(), "WEBLOG_LIMITS_BURST:") {
+		t.Errorf("applyOverrides returned %v, want an error of WEBLOG_LIMITS_BURST", err)
+	}
+
+	t.Setenv("WEBLOG_LIMITS_BURST", "5")
+	setFlag(t, "auth-enabled", "maybe")
+	if err := config.applyOverrides(); err == nil || !strings.HasPrefix(err.Error(), "-auth-enabled:") {
+		t.Errorf("applyOverrides returned %v, want an error of -auth-enabled", err)
+	}
+}
+
+func TestPrintMasksSecrets(t *testing.T) {
+	config := defaultConfiguration()
+	config.Auth = authConfig{Enabled: true, APIKeys: []apiKey{{Key: "reader-secret-key", Principal: "reader", Role: roleReader}}, JWTSecret: "jwt-secret-value"}
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	config.print()
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	for _, secret := range []string{"reader-secret-key", "jwt-secret-value"} {
+		if strings.Contains(string(out), secret) {
+			t.Errorf("print shows %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(string(out), `"principal": "reader"`) {
+		t.Errorf("print lost the principal: %s", out)
+	}
+	if config.Auth.APIKeys[0].Key != "reader-secret-key" || config.Auth.JWTSecret != "jwt-secret-value" {
+		t.Errorf("print changed the config: %+v", config.Auth)
+	}
+}
+
+func TestMissingConfFile(t *testing.T) {
+	useConfFile(t, filepath.Join(t.TempDir(), "conf.json"), false)
+	config := defaultConfiguration()
+	if err := config.getEnvVariables(); err != nil {
+		t.Fatalf("missing default conf.json returned %v", err)
+	}
+	if config.Port != defaultConfiguration().Port || config.StorePath != savedJSONFile {
+		t.Errorf("missing conf.json gave %+v, want the defaults", config)
+	}
+
+	// a file named by -config or WEBLOG_CONFIG must exist
+	confFileGiven = true
+	if err := config.getEnvVariables(); !os.IsNotExist(err) {
+		t.Errorf("missing -config file returned %v", err)
+	}
+}
+
+func TestConfFilePathsAreRelativeToItsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	abs := filepath.Join(t.TempDir(), "comments.json")
+	if err := os.WriteFile(filepath.Join(dir, "conf.json"), []byte(`{
+		"errorLogPath": "../logger/error.log",
+		"storePath": "saveArticles.json",
+		"commentsPath": "`+abs+`",
+		"levelLogPaths": {"debug": "debug.log"}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useConfFile(t, filepath.Join(dir, "conf.json"), true)
+	t.Setenv("WEBLOG_WAL_PATH", "wal/saveArticles.wal")
+
+	config := defaultConfiguration()
+	if err := config.getEnvVariables(); err != nil {
+		t.Fatal(err)
+	}
+	for name, test := range map[string]struct{ got, want string }{
+		"errorLogPath":        {config.ErrorLogPath, filepath.Join(filepath.Dir(dir), "logger/error.log")},
+		"storePath":           {config.StorePath, filepath.Join(dir, "saveArticles.json")},
+		"commentsPath":        {config.CommentsPath, abs},
+		"levelLogPaths.debug": {config.LevelLogPaths["debug"], filepath.Join(dir, "debug.log")},
+		// defaults and overrides stay relative to the working directory
+		"accessLogPath": {config.AccessLogPath, accessLogFilePath},
+		"walPath":       {config.WALPath, "wal/saveArticles.wal"},
+	} {
+		if test.got != test.want {
+			t.Errorf("%s is %s, want %s", name, test.got, test.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"net"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// limitsConfig is the "limits" section of conf.json, zero means unlimited
+type limitsConfig struct {
+	RequestsPerSecond    float64 `json:"requestsPerSecond"`
+	Burst                int     `json:"burst"`
+	MaxArticlesPerStream int     `json:"maxArticlesPerStream"`
+	MaxStreamBytes       int     `json:"maxStreamBytes"`
+	MaxRecvMsgSize       int     `json:"maxRecvMsgSize"`
+	MaxSendMsgSize       int     `json:"maxSendMsgSize"`
+}
+
+// how long an idle client keeps its token bucket
+const visitorIdleTimeout = 3 * time.Minute
+
+type visitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter keeps one token bucket per principal or client IP
+type rateLimiter struct {
+	config    limitsConfig
+	mu        sync.Mutex
+	visitors  map[string]*visitor
+	lastSweep time.Time
+}
+
+func newRateLimiter(config limitsConfig) *rateLimiter {
+	return &rateLimiter{
+		config:    config,
+		visitors:  make(map[string]*visitor),
+		lastSweep: time.Now(),
+	}
+}
+
+// Server options for the max message sizes
+func (config limitsConfig) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if config.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(config.MaxSendMsgSize))
+	}
+	return opts
+}
+
+// Rate limit by principal, or by client IP when the caller is anonymous
+func getLimiterKey(ctx context.Context) string {
+	if name := getPrincipal(ctx); name != "" && name != anonymousPrincipal.Name {
+		return "principal:" + name
+	}
+	addr := getClientIP(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "ip:" + addr
+}
+
+// Replace the limits when conf.json is reloaded, buckets restart with the new rate
+func (l *rateLimiter) setConfig(config limitsConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if config.RequestsPerSecond != l.config.RequestsPerSecond || config.Burst != l.config.Burst {
+		l.visitors = make(map[string]*visitor)
+	}
+	l.config = config
+}
+
+func (l *rateLimiter) currentConfig() limitsConfig {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.config
+}
+
+// Check whether the client still has a token in its bucket
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.config.RequestsPerSecond <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > visitorIdleTimeout {
+		for k, v := range l.visitors {
+			if now.Sub(v.lastSeen) > visitorIdleTimeout {
+				delete(l.visitors, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	v, ok := l.visitors[key]
+	if !ok {
+		burst := l.config.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		v = &visitor{limiter: rate.NewLimiter(rate.Limit(l.config.RequestsPerSecond), burst)}
+		l.visitors[key] = v
+	}
+	v.lastSeen = now
+	return v.limiter.Allow()
+}
+
+// Return a ResourceExhausted error and record it in error.log
+func limitExceeded(ctx context.Context, fullMethod string, msg string) error {
+	errorLogOf(ctx).ErrorPrintln(path.Base(fullMethod), msg)
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// unaryInterceptor rejects calls over the client's rate
+func (l *rateLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !l.allow(getLimiterKey(ctx)) {
+		return nil, limitExceeded(ctx, info.FullMethod, "rate limit exceeded")
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor rejects streams over the client's rate and caps what a stream may upload
+func (l *rateLimiter) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !l.allow(getLimiterKey(ss.Context())) {
+		return limitExceeded(ss.Context(), info.FullMethod, "rate limit exceeded")
+	}
+	return handler(srv, &limitedStream{ServerStream: ss, config: l.currentConfig(), fullMethod: info.FullMethod})
+}
+
+// limitedStream counts the messages and bytes received on a client stream
+type limitedStream struct {
+	grpc.ServerStream
+	config     limitsConfig
+	fullMethod string
+	messages   int
+	bytes      int
+}
+
+func (s *limitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.messages++
+	if msg, ok := m.(proto.Message); ok {
+		s.bytes += proto.Size(msg)
+	}
+
+	if s.config.MaxArticlesPerStream > 0 && s.messages > s.config.MaxArticlesPerStream {
+		return limitExceeded(s.Context(), s.fullMethod,
+			"stream exceeds "+strconv.Itoa(s.config.MaxArticlesPerStream)+" articles")
+	}
+	if s.config.MaxStreamBytes > 0 && s.bytes > s.config.MaxStreamBytes {
+		return limitExceeded(s.Context(), s.fullMethod,
+			"stream exceeds "+strconv.Itoa(s.config.MaxStreamBytes)+" bytes")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const saveAllArticles = "/web_log.WebLogService/SaveAllArticles"
+
+// articleStream is a SaveAllArticles stream receiving the articles
+type articleStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	articles []string
+}
+
+func (s *articleStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *articleStream) RecvMsg(m interface{}) error {
+	if len(s.articles) == 0 {
+		return io.EOF
+	}
+	m.(*web_log_pb.SaveAllArticlesRequest).Article = s.articles[0]
+	s.articles = s.articles[1:]
+	return nil
+}
+
+// Run a SaveAllArticles stream through the limiter, the handler reads until an error
+func runStream(l *rateLimiter, ctx context.Context, articles ...string) (int, error) {
+	received := 0
+	err := l.streamInterceptor(nil, &articleStream{ctx: ctx, articles: articles}, &grpc.StreamServerInfo{FullMethod: saveAllArticles},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			for {
+				if err := ss.RecvMsg(&web_log_pb.SaveAllArticlesRequest{}); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				received++
+			}
+		})
+	return received, err
+}
+
+// Get a context of a caller with the principal
+func asPrincipal(name string) context.Context {
+	return context.WithValue(context.Background(), principalKey{}, principal{Name: name, Role: roleEditor})
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	l := newRateLimiter(limitsConfig{RequestsPerSecond: 20, Burst: 2})
+	if !l.allow("principal:a") || !l.allow("principal:a") {
+		t.Fatal("burst of 2 was refused")
+	}
+	if l.allow("principal:a") {
+		t.Fatal("third call in a burst of 2 was allowed")
+	}
+	// 20 per second gives a token every 50ms
+	time.Sleep(100 * time.Millisecond)
+	if !l.allow("principal:a") {
+		t.Error("bucket did not refill")
+	}
+}
+
+func TestRateLimitIsPerPrincipal(t *testing.T) {
+	l := newRateLimiter(limitsConfig{RequestsPerSecond: 1, Burst: 1})
+	jane, john := asPrincipal("jane"), asPrincipal("john")
+	anonymous := func(ip string) context.Context {
+		ctx := context.WithValue(context.Background(), principalKey{}, anonymousPrincipal)
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}})
+	}
+
+	call := func(ctx context.Context) error {
+		_, err := l.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/web_log.WebLogService/GetAllArticles"},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		return err
+	}
+	for _, test := range []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"jane", jane, codes.OK},
+		{"jane again", jane, codes.ResourceExhausted},
+		{"john", john, codes.OK},
+		{"anonymous client", anonymous("10.0.0.1"), codes.OK},
+		{"anonymous client again", anonymous("10.0.0.1"), codes.ResourceExhausted},
+		{"other anonymous client", anonymous("10.0.0.2"), codes.OK},
+	} {
+		if err := call(test.ctx); status.Code(err) != test.want {
+			t.Errorf("%s: returned %v, want %v", test.name, err, test.want)
+		}
+	}
+	if key := getLimiterKey(anonymous("10.0.0.1")); key != "ip:10.0.0.1" {
+		t.Errorf("anonymous key is %s, want the client IP without port", key)
+	}
+}
+
+func TestStreamCaps(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		config   limitsConfig
+		articles []string
+		want     codes.Code
+		received int
+	}{
+		{"under the caps", limitsConfig{MaxArticlesPerStream: 3, MaxStreamBytes: 100}, []string{"a\na", "b\nb", "c\nc"}, codes.OK, 3},
+		{"too many articles", limitsConfig{MaxArticlesPerStream: 2}, []string{"a\na", "b\nb", "c\nc"}, codes.ResourceExhausted, 2},
+		{"too many bytes", limitsConfig{MaxStreamBytes: 30}, []string{strings.Repeat("a", 20), strings.Repeat("b", 20)}, codes.ResourceExhausted, 1},
+		{"no caps", limitsConfig{}, []string{"a\na", "b\nb", "c\nc"}, codes.OK, 3},
+	} {
+		received, err := runStream(newRateLimiter(test.config), asPrincipal("jane"), test.articles...)
+		if status.Code(err) != test.want || received != test.received {
+			t.Errorf("%s: received %d articles and returned %v, want %d and %v", test.name, received, err, test.received, test.want)
+		}
+	}
+}
+
+func TestSetConfigAppliesLive(t *testing.T) {
+	l := newRateLimiter(limitsConfig{RequestsPerSecond: 1, Burst: 1, MaxArticlesPerStream: 1})
+	ctx := asPrincipal("jane")
+	if _, err := runStream(l, ctx, "a\na"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runStream(l, ctx, "a\na"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second stream returned %v, want ResourceExhausted", err)
+	}
+
+	// a new rate gives fresh buckets, new streams get the new caps
+	l.setConfig(limitsConfig{RequestsPerSecond: 100, Burst: 10, MaxArticlesPerStream: 3})
+	if received, err := runStream(l, ctx, "a\na", "b\nb", "c\nc"); err != nil || received != 3 {
+		t.Errorf("stream after setConfig received %d articles and returned %v", received, err)
+	}
+
+	// unlimited
+	l.setConfig(limitsConfig{})
+	for i := 0; i < 50; i++ {
+		if !l.allow("principal:jane") {
+			t.Fatalf("call %d refused without a rate", i)
+		}
+	}
+}
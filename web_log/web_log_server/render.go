@@ -0,0 +1,88 @@
+package main
+
+import (
+	"crypto/sha256"
+	"grpc_web_log/web_log/web_log_pb"
+	"grpc_web_log/webrender"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rendered articles kept in memory, the oldest are forgotten first
+const renderCacheSize = 256
+
+// Check a content format of a request, empty is allowed
+func checkContentFormat(format string) error {
+	if webrender.ValidFormat(format) {
+		return nil
+	}
+	return status.Error(codes.InvalidArgument, "contentFormat "+strconv.Quote(format)+" is not plain, markdown or html")
+}
+
+// Get the content format of an article, plain for articles saved without one
+func contentFormatOf(article Article) string {
+	if article.ContentFormat == "" {
+		return webrender.Plain
+	}
+	return article.ContentFormat
+}
+
+// renderKey identifies a rendering, a new version of the article gets a new key, and so does content
+// changed without a new version, such as an edit of saveArticles.json
+type renderKey struct {
+	articleID string
+	version   int64
+	format    string
+	content   [sha256.Size]byte
+}
+
+// renderCache keeps the latest renderings
+type renderCache struct {
+	mu      sync.Mutex
+	entries map[renderKey]*webrender.Rendered
+	// keys in insertion order, the first is evicted first
+	order []renderKey
+}
+
+var renders = &renderCache{entries: make(map[renderKey]*webrender.Rendered)}
+
+// Get the rendering of an article from the cache, rendering it on a miss
+func (c *renderCache) get(article Article) (*webrender.Rendered, error) {
+	key := renderKey{articleID: article.ArticleID, version: article.Version, format: contentFormatOf(article), content: sha256.Sum256([]byte(article.Content))}
+	c.mu.Lock()
+	r, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		renderCacheLookups.WithLabelValues("hit").Inc()
+		return r, nil
+	}
+	renderCacheLookups.WithLabelValues("miss").Inc()
+
+	r, err := webrender.Render(article.Content, key.format)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = r
+		c.order = append(c.order, key)
+		if len(c.order) > renderCacheSize {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	return r, nil
+}
+
+// Get the table of contents of a rendering for a RenderArticleResponse
+func tocOf(r *webrender.Rendered) []*web_log_pb.TocEntry {
+	toc := make([]*web_log_pb.TocEntry, 0, len(r.TOC))
+	for _, heading := range r.TOC {
+		toc = append(toc, &web_log_pb.TocEntry{Level: int32(heading.Level), Id: heading.ID, Text: heading.Text})
+	}
+	return toc
+}
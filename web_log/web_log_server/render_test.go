@@ -0,0 +1,20 @@
+package main
+
+import (
+	"grpc_web_log/webrender"
+	"strings"
+	"testing"
+)
+
+func TestRenderCacheSeesContentChangedWithoutVersion(t *testing.T) {
+	c := &renderCache{entries: make(map[renderKey]*webrender.Rendered)}
+	article := Article{ArticleID: "a", Version: 1, Content: "first"}
+	if r, err := c.get(article); err != nil || !strings.Contains(r.HTML, "first") {
+		t.Fatalf("got %v, %v", r, err)
+	}
+	// saveArticles.json edited by hand keeps the version
+	article.Content = "second"
+	if r, err := c.get(article); err != nil || !strings.Contains(r.HTML, "second") {
+		t.Errorf("got %v, %v, want the new content", r, err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// default seconds to let in-flight RPCs finish on shutdown
+const defaultShutdownTimeout = 30
+
+// Serve srv in the background until it is shut down, a failure is logged with the method
+func serveHTTP(srv *http.Server, method string, failure string) {
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errorWebLogger.Error(method, failure, err)
+		}
+	}()
+}
+
+// backgroundLoops are the loops changing the article store, stopped before the store is closed
+type backgroundLoops struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newBackgroundLoops() *backgroundLoops {
+	return &backgroundLoops{stop: make(chan struct{})}
+}
+
+// Run a loop in the background, it returns when stop is closed
+func (l *backgroundLoops) run(loop func(stop <-chan struct{})) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		loop(l.stop)
+	}()
+}
+
+// Stop the loops and wait for the current run of each to finish
+func (l *backgroundLoops) stopAll() {
+	close(l.stop)
+	l.wg.Wait()
+}
+
+// Block until SIGINT or SIGTERM, then drain in-flight HTTP requests and RPCs, stop the background loops
+// and compact pending store writes. A nil HTTP server is skipped.
+func waitForShutdown(s *grpc.Server, healthServer *health.Server, httpServers []*http.Server, loops *backgroundLoops, timeout int) {
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigs
+	signal.Stop(sigs)
+	pc, _, _, _ := runtime.Caller(0)
+	errorWebLogger.Info(getCurrentRPCmethod(pc), "Server received", sig, "and is shutting down")
+
+	// health checks report NOT_SERVING while draining
+	healthServer.Shutdown()
+	// WatchArticles streams never end by themselves
+	watchers.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	// the gateway and gRPC-Web listeners call the gRPC server, stop them first
+	for _, srv := range httpServers {
+		if srv == nil {
+			continue
+		}
+		if err := srv.Shutdown(ctx); err != nil {
+			errorWebLogger.Warn(getCurrentRPCmethod(pc), "HTTP shutdown timed out, closing remaining connections.", srv.Addr, err)
+			srv.Close()
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		errorWebLogger.Warn(getCurrentRPCmethod(pc), "Graceful stop timed out, closing remaining connections.")
+		s.Stop()
+	}
+
+	// no scheduled publish or compaction runs while the store is closed
+	loops.stopAll()
+	// compact the write-ahead log into saveArticles.json
+	if err := store.close(context.Background()); err != nil {
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Failed to close article store.", err)
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"grpc_web_log/webarticle"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// storeConfig is the "store" section of conf.json
+type storeConfig struct {
+	// seconds between two background compactions of the write-ahead log
+	CompactInterval int `json:"compactInterval"`
+	// compact as soon as the write-ahead log grows past this many bytes
+	CompactBytes int64 `json:"compactBytes"`
+}
+
+// default seconds between two compactions
+const defaultCompactInterval = 60
+
+// storeStep is called after each step that changes the files of the store: "append" to the write-ahead log,
+// "snapshot" of saveArticles.json and "truncate" of the log. Tests use it to crash the store between two steps.
+var storeStep = func(step string) {}
+
+// errStoreClosed is returned for a change after the store was closed on shutdown
+var errStoreClosed = errors.New("article store is closed")
+
+// errUnchanged tells store.update that change left the article as it was, so nothing is written
+var errUnchanged = errors.New("article is unchanged")
+
+// articleStore keeps the articles of saveArticles.json in memory, indexed by articleID.
+// Every change is appended to a fsynced write-ahead log before it is acknowledged, and the
+// log is compacted into saveArticles.json in the background. The snapshot is reloaded, and
+// the log replayed on top of it, when the file's mtime or size changes.
+type articleStore struct {
+	mu       sync.RWMutex
+	path     string
+	wal      *writeAheadLog
+	articles Articles
+	index    map[string]int // articleID -> position in articles
+	modTime  time.Time
+	size     int64
+	// when the articles last changed, by a commit or a reload
+	changed time.Time
+	config   storeConfig
+	// signals compactLoop that the write-ahead log grew past config.CompactBytes
+	compactNow chan struct{}
+	// set by close, later changes are refused
+	closed bool
+}
+
+// Open the store, replay the write-ahead log left by a crash and compact it
+func openArticleStore(ctx context.Context, path string, walPath string, config storeConfig) (*articleStore, error) {
+	wal, err := openWriteAheadLog(walPath)
+	if err != nil {
+		return nil, err
+	}
+	st := &articleStore{
+		path:       path,
+		wal:        wal,
+		index:      make(map[string]int),
+		config:     config,
+		compactNow: make(chan struct{}, 1),
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.load(ctx)
+	if err := st.snapshot(ctx); err != nil {
+		wal.close()
+		return nil, err
+	}
+	return st, nil
+}
+
+// Check whether the file was changed by someone else since it was last read or written
+func (st *articleStore) isStale() bool {
+	info, err := os.Stat(st.path)
+	if err != nil {
+		// getJSONData creates a missing file
+		return true
+	}
+	return !info.ModTime().Equal(st.modTime) || info.Size() != st.size
+}
+
+// Remember the file mtime and size after a read or write
+func (st *articleStore) stat() {
+	if info, err := os.Stat(st.path); err == nil {
+		st.modTime = info.ModTime()
+		st.size = info.Size()
+	}
+}
+
+// Rebuild the articleID index
+func (st *articleStore) reindex() {
+	st.index = webarticle.Index(st.articles)
+}
+
+// Reload the snapshot if it changed and replay the write-ahead log on it, must hold st.mu.
+// The watchers are told about the articles changed by someone else, but not on the first load.
+func (st *articleStore) load(ctx context.Context) {
+	if !st.isStale() {
+		return
+	}
+	before, loaded := st.articles, !st.modTime.IsZero()
+	jsonData := getJSONData(ctx, st.path)
+	st.articles = getCurrentArticles(ctx, jsonData)
+	st.reindex()
+	st.stat()
+	st.changed = st.modTime
+
+	records, err := st.wal.records()
+	if err != nil {
+		pc, _, _, _ := runtime.Caller(0)
+		errorLogOf(ctx).Error(getCurrentRPCmethod(pc), "Read write-ahead log error.", err)
+	}
+	for _, record := range records {
+		st.articles = st.articles.apply(st.index, record)
+	}
+	articlesStored.Set(float64(len(st.articles)))
+	if loaded {
+		watchers.publish(diffArticles(before, st.articles)...)
+	}
+}
+
+// Get the events turning the articles before into the articles after
+func diffArticles(before Articles, after Articles) []articleEvent {
+	var events []articleEvent
+	old := webarticle.Index(before)
+	for _, article := range after {
+		article := article
+		i, ok := old[article.ArticleID]
+		if !ok {
+			events = append(events, articleEvent{after: &article})
+			continue
+		}
+		delete(old, article.ArticleID)
+		if previous := before[i]; !sameArticle(previous, article) {
+			events = append(events, articleEvent{before: &previous, after: &article})
+		}
+	}
+	for _, article := range before {
+		article := article
+		if _, ok := old[article.ArticleID]; ok {
+			events = append(events, articleEvent{before: &article})
+		}
+	}
+	return events
+}
+
+// Compare two articles as they are stored, times read back from the file lose their monotonic clock
+func sameArticle(a Article, b Article) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return bytes.Equal(aJSON, bJSON)
+}
+
+// Take the read lock on fresh articles, release with st.mu.RUnlock()
+func (st *articleStore) rlock(ctx context.Context) {
+	st.mu.RLock()
+	if !st.isStale() {
+		return
+	}
+	st.mu.RUnlock()
+	st.mu.Lock()
+	st.load(ctx)
+	st.mu.Unlock()
+	st.mu.RLock()
+}
+
+// Log the records, then apply them to the articles and tell the watchers, must hold st.mu
+func (st *articleStore) commit(ctx context.Context, records ...walRecord) error {
+	if st.closed {
+		return errStoreClosed
+	}
+	if err := st.wal.append(ctx, records...); err != nil {
+		pc, _, _, _ := runtime.Caller(0)
+		errorLogOf(ctx).Error(getCurrentRPCmethod(pc), "Append to write-ahead log error.", err)
+		return err
+	}
+	storeStep("append")
+	events := make([]articleEvent, 0, len(records))
+	for _, record := range records {
+		var event articleEvent
+		if i, ok := st.index[record.Article.ArticleID]; ok {
+			before := st.articles[i]
+			event.before = &before
+		}
+		st.articles = st.articles.apply(st.index, record)
+		if record.Op != walRemove {
+			after := record.Article
+			event.after = &after
+		}
+		if event.before != nil || event.after != nil {
+			events = append(events, event)
+		}
+	}
+	articlesStored.Set(float64(len(st.articles)))
+	st.changed = time.Now()
+	watchers.publish(events...)
+
+	if st.config.CompactBytes > 0 && st.wal.size >= st.config.CompactBytes {
+		select {
+		case st.compactNow <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Write the articles to saveArticles.json and empty the write-ahead log, must hold st.mu
+func (st *articleStore) snapshot(ctx context.Context) error {
+	if err := st.articles.write2jsonFile(ctx, st.path); err != nil {
+		return err
+	}
+	st.stat()
+	storeStep("snapshot")
+	// a crash before the truncate replays records already in the snapshot, which is harmless
+	if err := st.wal.truncate(); err != nil {
+		return err
+	}
+	storeStep("truncate")
+	return nil
+}
+
+// Compact the write-ahead log every interval, or sooner when it grows past CompactBytes, until stop is closed
+func (st *articleStore) compactLoop(stop <-chan struct{}) {
+	interval := st.config.CompactInterval
+	if interval <= 0 {
+		interval = defaultCompactInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-st.compactNow:
+		}
+		st.mu.Lock()
+		if st.wal.size > 0 && !st.closed {
+			st.snapshot(context.Background())
+		}
+		st.mu.Unlock()
+	}
+}
+
+// Compact the write-ahead log and close it, the store accepts no more changes
+func (st *articleStore) close(ctx context.Context) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.closed {
+		return nil
+	}
+	st.closed = true
+	err := st.snapshot(ctx)
+	if closeErr := st.wal.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Get a copy of all articles in file order
+func (st *articleStore) all(ctx context.Context) Articles {
+	st.rlock(ctx)
+	defer st.mu.RUnlock()
+	return append(Articles(nil), st.articles...)
+}
+
+// Get the time the articles last changed, removals and status changes included
+func (st *articleStore) modified(ctx context.Context) time.Time {
+	st.rlock(ctx)
+	defer st.mu.RUnlock()
+	return st.changed
+}
+
+// Get a copy of the article with the articleID
+func (st *articleStore) get(ctx context.Context, articleID string) (Article, bool) {
+	st.rlock(ctx)
+	defer st.mu.RUnlock()
+	i, ok := st.index[articleID]
+	if !ok {
+		return Article{}, false
+	}
+	article := st.articles[i]
+	// the caller must not change the tags of the stored article
+	article.Tags = append([]string(nil), article.Tags...)
+	return article, true
+}
+
+// Append new articles
+func (st *articleStore) add(ctx context.Context, articles ...Article) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.load(ctx)
+	now := time.Now().UTC()
+	records := make([]walRecord, 0, len(articles))
+	for _, article := range articles {
+		if article.Version == 0 {
+			article.Version = 1
+		}
+		if article.Created.IsZero() {
+			article.Created = now
+			article.Updated = now
+		}
+		records = append(records, walRecord{Op: walSave, Article: article})
+	}
+	return st.commit(ctx, records...)
+}
+
+// Change an article and bump its version, false if the articleID does not exist.
+// Nothing is written when change returns an error, errUnchanged gets the current article without an error.
+func (st *articleStore) update(ctx context.Context, articleID string, change func(article *Article) error) (Article, bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.load(ctx)
+	i, ok := st.index[articleID]
+	if !ok {
+		return Article{}, false, nil
+	}
+	article := st.articles[i]
+	article.Tags = append([]string(nil), article.Tags...)
+	if err := change(&article); err == errUnchanged {
+		return st.articles[i], true, nil
+	} else if err != nil {
+		return Article{}, true, err
+	}
+	article.Version++
+	article.Updated = time.Now().UTC()
+	return article, true, st.commit(ctx, walRecord{Op: walUpdate, Article: article})
+}
+
+// Set the title, content and contentFormat of an article that are not nil, an empty contentFormat keeps the current one
+func setContent(title *string, content *string, contentFormat *string) func(article *Article) error {
+	return func(article *Article) error {
+		if title != nil {
+			article.Title = *title
+		}
+		if content != nil {
+			article.Content = *content
+		}
+		if contentFormat != nil && *contentFormat != "" {
+			article.ContentFormat = *contentFormat
+		}
+		return nil
+	}
+}
+
+// Remove an article, false if the articleID does not exist
+func (st *articleStore) remove(ctx context.Context, articleID string) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.load(ctx)
+	if _, ok := st.index[articleID]; !ok {
+		return false, nil
+	}
+	return true, st.commit(ctx, walRecord{Op: walRemove, Article: Article{ArticleID: articleID}})
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"grpc_web_log/weblogger"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// articles in the benchmark store
+const benchArticles = 1000
+
+// Open a store of benchArticles articles as the global store
+func openBenchStore(b *testing.B) {
+	b.Helper()
+	errorWebLogger.SetLevel(weblogger.LevelError)
+	dir := b.TempDir()
+	st, err := openArticleStore(context.Background(), filepath.Join(dir, "saveArticles.json"), filepath.Join(dir, "saveArticles.wal"), storeConfig{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	articles := make([]Article, 0, benchArticles)
+	for i := 0; i < benchArticles; i++ {
+		id := strconv.Itoa(i)
+		articles = append(articles, Article{ArticleID: id, Title: "title " + id, Content: "content " + id, Tags: []string{"go"}})
+	}
+	if err := st.add(context.Background(), articles...); err != nil {
+		b.Fatal(err)
+	}
+	st.mu.Lock()
+	st.snapshot(context.Background())
+	st.mu.Unlock()
+	store = st
+	b.Cleanup(func() { st.wal.close() })
+}
+
+// GetSpecifiedArticle served from the in-memory store
+func BenchmarkGetSpecifiedArticle(b *testing.B) {
+	openBenchStore(b)
+	ctx := context.Background()
+	req := &web_log_pb.GetSpecifiedArticleRequest{ArticleID: strconv.Itoa(benchArticles / 2)}
+	s := &server{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetSpecifiedArticle(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// GetSpecifiedArticle reading and decoding saveArticles.json on every request, as before the store cached it
+func BenchmarkGetSpecifiedArticleReload(b *testing.B) {
+	openBenchStore(b)
+	ctx := context.Background()
+	articleID := strconv.Itoa(benchArticles / 2)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		found := false
+		for _, article := range getCurrentArticles(ctx, getJSONData(ctx, store.path)) {
+			if article.ArticleID == articleID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			b.Fatal("article not found")
+		}
+	}
+}
+
+// GetAllArticles served from the in-memory store
+func BenchmarkGetAllArticles(b *testing.B) {
+	openBenchStore(b)
+	ctx := context.Background()
+	s := &server{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetAllArticles(ctx, &web_log_pb.GetAllArticlesRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// GetAllArticles reading and decoding saveArticles.json on every request
+func BenchmarkGetAllArticlesReload(b *testing.B) {
+	openBenchStore(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(getCurrentArticles(ctx, getJSONData(ctx, store.path))) != benchArticles {
+			b.Fatal("articles missing")
+		}
+	}
+}
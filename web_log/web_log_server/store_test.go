@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// crash stops the store after a step, like a power cut
+type crash struct{ step string }
+
+// Run fn and stop it with a crash after the step
+func crashAfter(t *testing.T, step string, fn func()) {
+	t.Helper()
+	storeStep = func(s string) {
+		if s == step {
+			panic(crash{s})
+		}
+	}
+	defer func() {
+		storeStep = func(string) {}
+		if r, ok := recover().(crash); !ok || r.step != step {
+			t.Fatalf("store did not stop after %q", step)
+		}
+	}()
+	fn()
+}
+
+func openTestStore(t *testing.T, dir string) *articleStore {
+	t.Helper()
+	st, err := openArticleStore(context.Background(), filepath.Join(dir, "saveArticles.json"), filepath.Join(dir, "saveArticles.wal"), storeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+// Drop the store without compacting, like a killed process
+func abandon(st *articleStore) {
+	st.wal.close()
+}
+
+// Check the articles of a reopened store, by articleID and version
+func checkRecovered(t *testing.T, dir string, want map[string]int64) *articleStore {
+	t.Helper()
+	st := openTestStore(t, dir)
+	articles := st.all(context.Background())
+	if len(articles) != len(want) {
+		t.Fatalf("recovered %d articles, want %d: %+v", len(articles), len(want), articles)
+	}
+	for _, article := range articles {
+		version, ok := want[article.ArticleID]
+		if !ok || version != article.Version {
+			t.Errorf("recovered %s version %d, want %v", article.ArticleID, article.Version, want)
+		}
+	}
+	return st
+}
+
+func TestCrashAfterAppend(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	if err := st.add(ctx, Article{ArticleID: "a", Title: "a", Content: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	a2 := "a2"
+	crashAfter(t, "append", func() {
+		st.update(ctx, "a", setContent(&a2, &a2, nil))
+	})
+	abandon(st)
+
+	// the update was acknowledged once it was in the log
+	st = checkRecovered(t, dir, map[string]int64{"a": 2})
+	abandon(st)
+}
+
+func TestCrashAfterAppendOfRemove(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	if err := st.add(ctx, Article{ArticleID: "a"}, Article{ArticleID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	crashAfter(t, "append", func() {
+		st.remove(ctx, "a")
+	})
+	abandon(st)
+
+	st = checkRecovered(t, dir, map[string]int64{"b": 1})
+	abandon(st)
+}
+
+func TestCrashAfterSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	if err := st.add(ctx, Article{ArticleID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	a2 := "a2"
+	if _, _, err := st.update(ctx, "a", setContent(&a2, &a2, nil)); err != nil {
+		t.Fatal(err)
+	}
+	crashAfter(t, "snapshot", func() {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		st.snapshot(ctx)
+	})
+	abandon(st)
+
+	// the log still holds the records already in the snapshot, replaying them gives no duplicate
+	if info, err := os.Stat(filepath.Join(dir, "saveArticles.wal")); err != nil || info.Size() == 0 {
+		t.Fatalf("write-ahead log was truncated before the crash: %v", err)
+	}
+	st = checkRecovered(t, dir, map[string]int64{"a": 2})
+	abandon(st)
+}
+
+func TestCrashAfterTruncate(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	if err := st.add(ctx, Article{ArticleID: "a"}, Article{ArticleID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	crashAfter(t, "truncate", func() {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		st.snapshot(ctx)
+	})
+	abandon(st)
+
+	st = checkRecovered(t, dir, map[string]int64{"a": 1, "b": 1})
+	if st.wal.size != 0 {
+		t.Errorf("write-ahead log has %d bytes after recovery, want 0", st.wal.size)
+	}
+	abandon(st)
+}
+
+func TestTornRecordIsSkipped(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	if err := st.add(ctx, Article{ArticleID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	abandon(st)
+	// a crash in the middle of an append leaves a line without '\n'
+	file, err := os.OpenFile(filepath.Join(dir, "saveArticles.wal"), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"op":"save","article":{"articleID":"b"`)
+	file.Close()
+
+	st = checkRecovered(t, dir, map[string]int64{"a": 1})
+	if err := st.add(ctx, Article{ArticleID: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	abandon(st)
+	st = checkRecovered(t, dir, map[string]int64{"a": 1, "c": 1})
+	abandon(st)
+}
+
+func TestCloseRefusesChanges(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	st := openTestStore(t, dir)
+	loops := newBackgroundLoops()
+	loops.run(st.compactLoop)
+	if err := st.add(ctx, Article{ArticleID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// shutdown stops the loops before closing the store
+	loops.stopAll()
+	if err := st.close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// the store is unlocked, reads still work and changes are refused
+	if _, ok := st.get(ctx, "a"); !ok {
+		t.Error("article a is gone after close")
+	}
+	if err := st.add(ctx, Article{ArticleID: "b"}); err != errStoreClosed {
+		t.Errorf("add after close returned %v, want errStoreClosed", err)
+	}
+	if err := st.close(ctx); err != nil {
+		t.Errorf("second close returned %v", err)
+	}
+	abandon(checkRecovered(t, dir, map[string]int64{"a": 1}))
+}
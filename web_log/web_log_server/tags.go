@@ -0,0 +1,210 @@
+package main
+
+import (
+	"grpc_web_log/web_log/web_log_pb"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// limits of the classification of an article
+const (
+	maxTags          = 32
+	maxTagRunes      = 64
+	maxCategoryRunes = 64
+)
+
+// ListArticles page sizes
+const (
+	defaultListPageSize = 20
+	maxListPageSize     = 100
+)
+
+// Trim the tags, drop empty ones and duplicates ignoring case, keeping the first spelling
+func normalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || containsFold(normalized, tag) {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagRunes {
+			return nil, status.Error(codes.InvalidArgument, "tag "+strconv.Quote(tag)+" is longer than "+strconv.Itoa(maxTagRunes)+" characters")
+		}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, status.Error(codes.InvalidArgument, "an article has at most "+strconv.Itoa(maxTags)+" tags")
+	}
+	return normalized, nil
+}
+
+// Trim a category, empty is no category
+func normalizeCategory(category string) (string, error) {
+	category = strings.TrimSpace(category)
+	if utf8.RuneCountInString(category) > maxCategoryRunes {
+		return "", status.Error(codes.InvalidArgument, "category is longer than "+strconv.Itoa(maxCategoryRunes)+" characters")
+	}
+	return category, nil
+}
+
+// Set the tags and category of a new article
+func classify(article *Article, tags []string, category string) error {
+	var err error
+	if article.Tags, err = normalizeTags(tags); err != nil {
+		return err
+	}
+	article.Category, err = normalizeCategory(category)
+	return err
+}
+
+// Check whether a list has s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check whether an article has a tag, ignoring case
+func hasTag(article Article, tag string) bool {
+	return containsFold(article.Tags, tag)
+}
+
+// Keep the articles with at least one of the tags, and count the others
+func filterTags(articles Articles, tags []string) (Articles, int) {
+	var kept Articles
+	for _, article := range articles {
+		for _, tag := range tags {
+			if hasTag(article, tag) {
+				kept = append(kept, article)
+				break
+			}
+		}
+	}
+	return kept, len(articles) - len(kept)
+}
+
+// Add tags to an article, the existing spelling of a tag is kept
+func addTags(tags []string) func(article *Article) error {
+	return func(article *Article) error {
+		added := false
+		for _, tag := range tags {
+			if !hasTag(*article, tag) {
+				article.Tags = append(article.Tags, tag)
+				added = true
+			}
+		}
+		if !added {
+			return errUnchanged
+		}
+		if len(article.Tags) > maxTags {
+			return status.Error(codes.InvalidArgument, "an article has at most "+strconv.Itoa(maxTags)+" tags")
+		}
+		return nil
+	}
+}
+
+// Remove tags from an article, ignoring case
+func removeTags(tags []string) func(article *Article) error {
+	return func(article *Article) error {
+		kept := article.Tags[:0]
+		for _, tag := range article.Tags {
+			if !containsFold(tags, tag) {
+				kept = append(kept, tag)
+			}
+		}
+		if len(kept) == len(article.Tags) {
+			return errUnchanged
+		}
+		article.Tags = kept
+		return nil
+	}
+}
+
+// Count the articles of each tag and category, by name, names differing only in case are counted together
+func countTags(articles Articles) (tags []*web_log_pb.TagCount, categories []*web_log_pb.TagCount) {
+	count := func(counts []*web_log_pb.TagCount, name string) []*web_log_pb.TagCount {
+		for _, c := range counts {
+			if strings.EqualFold(c.Name, name) {
+				c.Count++
+				return counts
+			}
+		}
+		return append(counts, &web_log_pb.TagCount{Name: name, Count: 1})
+	}
+	for _, article := range articles {
+		for _, tag := range article.Tags {
+			tags = count(tags, tag)
+		}
+		if article.Category != "" {
+			categories = count(categories, article.Category)
+		}
+	}
+	byName := func(counts []*web_log_pb.TagCount) {
+		sort.Slice(counts, func(i, j int) bool { return strings.ToLower(counts[i].Name) < strings.ToLower(counts[j].Name) })
+	}
+	byName(tags)
+	byName(categories)
+	return tags, categories
+}
+
+// Get a page of the articles with the tag and category, an empty filter matches every article.
+// The page token is the position of the first article of the page.
+func listArticles(articles Articles, req *web_log_pb.ListArticlesRequest) (*web_log_pb.ListArticlesResponse, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, status.Error(codes.InvalidArgument, "pageSize must not be negative")
+	case size == 0:
+		size = defaultListPageSize
+	case size > maxListPageSize:
+		size = maxListPageSize
+	}
+	start := 0
+	if req.GetPageToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetPageToken()); err != nil || start < 0 {
+			return nil, status.Error(codes.InvalidArgument, "pageToken is not valid")
+		}
+	}
+
+	var matched Articles
+	for _, article := range articles {
+		if req.GetTag() != "" && !hasTag(article, req.GetTag()) {
+			continue
+		}
+		if req.GetCategory() != "" && !strings.EqualFold(article.Category, req.GetCategory()) {
+			continue
+		}
+		matched = append(matched, article)
+	}
+
+	// a token past the end gives an empty page, start+size cannot overflow
+	if start > len(matched) {
+		start = len(matched)
+	}
+	res := &web_log_pb.ListArticlesResponse{TotalSize: int32(len(matched))}
+	for i := start; i < len(matched) && i < start+size; i++ {
+		article := matched[i]
+		res.Articles = append(res.Articles, &web_log_pb.ArticleSummary{
+			ArticleID: article.ArticleID,
+			Title:     article.Title,
+			Tags:      article.Tags,
+			Category:  article.Category,
+			Version:   article.Version,
+			Status:    statusPb(article),
+			PublishAt: publishAtOf(article),
+		})
+	}
+	if start+size < len(matched) {
+		res.NextPageToken = strconv.Itoa(start + size)
+	}
+	return res, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestTagChangesKeepVersionWhenUnchanged(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "a", Content: "a", Tags: []string{"Go"}}); err != nil {
+		t.Fatal(err)
+	}
+	s := &server{}
+
+	res, err := s.AddTags(ctx, &web_log_pb.AddTagsRequest{ArticleID: "a", Tags: []string{" go "}})
+	if err != nil || res.GetVersion() != 1 {
+		t.Errorf("adding a tag the article has returned version %d, %v, want 1", res.GetVersion(), err)
+	}
+	res, err = s.RemoveTags(ctx, &web_log_pb.RemoveTagsRequest{ArticleID: "a", Tags: []string{"rust"}})
+	if err != nil || res.GetVersion() != 1 {
+		t.Errorf("removing a tag the article does not have returned version %d, %v, want 1", res.GetVersion(), err)
+	}
+
+	res, err = s.RemoveTags(ctx, &web_log_pb.RemoveTagsRequest{ArticleID: "a", Tags: []string{" GO "}})
+	if err != nil || res.GetVersion() != 2 || len(res.GetTags()) != 0 {
+		t.Errorf("removing \" GO \" returned %v, %v, want no tags at version 2", res, err)
+	}
+}
+
+func TestListArticlesPageTokenPastTheEnd(t *testing.T) {
+	articles := Articles{{ArticleID: "a"}, {ArticleID: "b"}, {ArticleID: "c"}}
+	for _, token := range []string{"3", "4", strconv.Itoa(math.MaxInt)} {
+		res, err := listArticles(articles, &web_log_pb.ListArticlesRequest{PageSize: 2, PageToken: token})
+		if err != nil || len(res.GetArticles()) != 0 || res.GetNextPageToken() != "" || res.GetTotalSize() != 3 {
+			t.Errorf("pageToken %s returned %v, %v, want an empty last page", token, res, err)
+		}
+	}
+	res, err := listArticles(articles, &web_log_pb.ListArticlesRequest{PageSize: 2, PageToken: "2"})
+	if err != nil || len(res.GetArticles()) != 1 || res.GetArticles()[0].GetArticleID() != "c" || res.GetNextPageToken() != "" {
+		t.Errorf("pageToken 2 returned %v, %v, want c", res, err)
+	}
+}
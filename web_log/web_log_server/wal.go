@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"grpc_web_log/webarticle"
+	"os"
+)
+
+// write-ahead log operations
+const (
+	walSave   = webarticle.OpSave
+	walUpdate = webarticle.OpUpdate
+	walRemove = webarticle.OpRemove
+)
+
+// walRecord is one line of the write-ahead log
+type walRecord = webarticle.Record
+
+// writeAheadLog appends fsynced records of the changes not yet compacted into saveArticles.json
+type writeAheadLog struct {
+	path string
+	file *os.File
+	size int64
+}
+
+// Open the write-ahead log for appending, creating it if needed
+func openWriteAheadLog(path string) (*writeAheadLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &writeAheadLog{path: path, file: file, size: info.Size()}, nil
+}
+
+// Append records and fsync them, the change is acknowledged only when this returns nil
+func (wal *writeAheadLog) append(ctx context.Context, records ...walRecord) error {
+	defer observeStore("wal_append")()
+	_, span := tracer.Start(ctx, "walAppend")
+	defer span.End()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	_, err := wal.file.Write(buf.Bytes())
+	if err == nil {
+		err = wal.file.Sync()
+	}
+	if err != nil {
+		span.RecordError(err)
+		// drop a partly written record so later appends start on a clean line
+		wal.file.Truncate(wal.size)
+		return err
+	}
+	wal.size += int64(buf.Len())
+	return nil
+}
+
+// Read every complete record, a torn last line from a crash during append is skipped
+func (wal *writeAheadLog) records() ([]walRecord, error) {
+	file, err := os.Open(wal.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return webarticle.ReadRecords(file), nil
+}
+
+// Empty the log after its records were compacted into saveArticles.json
+func (wal *writeAheadLog) truncate() error {
+	if err := wal.file.Truncate(0); err != nil {
+		return err
+	}
+	wal.size = 0
+	return wal.file.Sync()
+}
+
+func (wal *writeAheadLog) close() error {
+	return wal.file.Close()
+}
+
+// Apply a record to the articles, replaying a record twice gives the same result
+func (currentArticles Articles) apply(index map[string]int, record walRecord) Articles {
+	return webarticle.Apply(currentArticles, index, record)
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// events kept for watchers resuming with a cursor, the oldest are forgotten first
+const watchBufferSize = 1024
+
+// events a watcher may fall behind before it is disconnected
+const watchQueueSize = 256
+
+// a watcher whose filter skipped events gets a heartbeat with the newest cursor at this interval,
+// or after watchBufferSize/2 skipped events, so its cursor does not fall out of the kept events
+var watchHeartbeatInterval = 30 * time.Second
+
+// articleEvent is a change committed to the article store
+type articleEvent struct {
+	seq    uint64
+	cursor string
+	time   time.Time
+	// nil when the article was saved
+	before *Article
+	// nil when the article was removed
+	after *Article
+}
+
+// watcher is a WatchArticles stream subscribed to the events
+type watcher struct {
+	events chan articleEvent
+	// closed when the watcher is disconnected by the hub, err tells why
+	done chan struct{}
+	err  error
+}
+
+// watchHub sends the events of the article store to the watchers and keeps the latest for resuming.
+// A cursor is the run of the server and the sequence number of an event, so cursors of an earlier run are refused.
+type watchHub struct {
+	mu       sync.Mutex
+	run      string
+	seq      uint64
+	events   []articleEvent
+	watchers map[*watcher]bool
+	closed   bool
+}
+
+var watchers = &watchHub{
+	run:      strconv.FormatInt(time.Now().UnixNano(), 36),
+	watchers: make(map[*watcher]bool),
+}
+
+// Number the events, keep them for resuming and send them to every watcher, a watcher that fell behind is disconnected
+func (h *watchHub) publish(events ...articleEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now().UTC()
+	for _, event := range events {
+		h.seq++
+		event.seq = h.seq
+		event.cursor = h.cursorOf(h.seq)
+		event.time = now
+		h.events = append(h.events, event)
+		if len(h.events) > watchBufferSize {
+			h.events = h.events[1:]
+		}
+		for w := range h.watchers {
+			select {
+			case w.events <- event:
+			default:
+				h.disconnect(w, status.Error(codes.ResourceExhausted, "watcher fell behind, resume from the cursor of the last event received"))
+			}
+		}
+	}
+}
+
+// Get the cursor of a sequence number
+func (h *watchHub) cursorOf(seq uint64) string {
+	return h.run + "-" + strconv.FormatUint(seq, 10)
+}
+
+// Get the sequence number of a cursor
+func (h *watchHub) parseCursor(cursor string) (uint64, error) {
+	i := strings.LastIndex(cursor, "-")
+	if i < 0 {
+		return 0, status.Error(codes.InvalidArgument, "cursor is not valid")
+	}
+	seq, err := strconv.ParseUint(cursor[i+1:], 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "cursor is not valid")
+	}
+	if cursor[:i] != h.run {
+		return 0, status.Error(codes.OutOfRange, "cursor is from an earlier run of the server, read the articles again")
+	}
+	if seq > h.seq {
+		return 0, status.Error(codes.InvalidArgument, "cursor is not valid")
+	}
+	return seq, nil
+}
+
+// Subscribe a watcher to the events, and get the events after the cursor it missed and the cursor of the newest event
+func (h *watchHub) subscribe(cursor string) (*watcher, []articleEvent, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, "", status.Error(codes.Unavailable, "server is shutting down")
+	}
+	var missed []articleEvent
+	if cursor != "" {
+		seq, err := h.parseCursor(cursor)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if seq < h.seq && (len(h.events) == 0 || h.events[0].seq > seq+1) {
+			return nil, nil, "", status.Error(codes.OutOfRange, "cursor is too old, read the articles again")
+		}
+		for _, event := range h.events {
+			if event.seq > seq {
+				missed = append(missed, event)
+			}
+		}
+	}
+	w := &watcher{events: make(chan articleEvent, watchQueueSize), done: make(chan struct{})}
+	h.watchers[w] = true
+	return w, missed, h.cursorOf(h.seq), nil
+}
+
+// Unsubscribe a watcher when its stream ends
+func (h *watchHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+// Disconnect a watcher, must hold h.mu
+func (h *watchHub) disconnect(w *watcher, err error) {
+	delete(h.watchers, w)
+	w.err = err
+	close(w.done)
+}
+
+// Disconnect every watcher and refuse new ones, so a graceful stop does not wait for them
+func (h *watchHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for w := range h.watchers {
+		h.disconnect(w, status.Error(codes.Unavailable, "server is shutting down"))
+	}
+}
+
+// watchFilter picks the events of a WatchArticlesRequest the caller may see
+type watchFilter struct {
+	tags           []string
+	articleIDs     []string
+	includeArticle bool
+	// editors see every article, readers the published ones
+	editor bool
+}
+
+func newWatchFilter(ctx context.Context, req *web_log_pb.WatchArticlesRequest) watchFilter {
+	return watchFilter{
+		tags:           req.GetTags(),
+		articleIDs:     req.GetArticleIDs(),
+		includeArticle: req.GetIncludeArticle(),
+		editor:         hasRole(ctx, roleEditor),
+	}
+}
+
+// Check whether the watcher sees an article
+func (f watchFilter) sees(article *Article) bool {
+	return article != nil && (f.editor || statusOf(*article) == statusPublished)
+}
+
+// Check whether an article is watched
+func (f watchFilter) matches(article *Article) bool {
+	if article == nil {
+		return false
+	}
+	if len(f.articleIDs) != 0 {
+		found := false
+		for _, articleID := range f.articleIDs {
+			found = found || articleID == article.ArticleID
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.tags) != 0 {
+		for _, tag := range f.tags {
+			if hasTag(*article, tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Get the event for the watcher, nil when it is filtered out.
+// For a reader an article becoming published is created, and one leaving published is deleted.
+func (f watchFilter) event(e articleEvent) *web_log_pb.ArticleEvent {
+	if !f.matches(e.before) && !f.matches(e.after) {
+		return nil
+	}
+	before, after := f.sees(e.before), f.sees(e.after)
+	res := &web_log_pb.ArticleEvent{Cursor: e.cursor, Time: e.time.Format(time.RFC3339)}
+	switch {
+	case !before && !after:
+		return nil
+	case !before:
+		res.Type = web_log_pb.ArticleEventType_CREATED
+	case !after:
+		res.Type = web_log_pb.ArticleEventType_DELETED
+	default:
+		res.Type = web_log_pb.ArticleEventType_UPDATED
+	}
+	article := e.after
+	if article == nil {
+		article = e.before
+	}
+	res.ArticleID = article.ArticleID
+	res.Version = article.Version
+	if f.includeArticle && res.Type != web_log_pb.ArticleEventType_DELETED {
+		res.Article = articlePb(*article)
+	}
+	return res
+}
+
+// Get a heartbeat moving the watcher to a cursor
+func heartbeatEvent(cursor string) *web_log_pb.ArticleEvent {
+	return &web_log_pb.ArticleEvent{Type: web_log_pb.ArticleEventType_HEARTBEAT, Cursor: cursor, Time: time.Now().UTC().Format(time.RFC3339)}
+}
+
+// Convert an article for a response
+func articlePb(article Article) *web_log_pb.Article {
+	return &web_log_pb.Article{
+		ArticleID:     article.ArticleID,
+		Title:         article.Title,
+		Content:       article.Content,
+		ContentFormat: contentFormatOf(article),
+		Version:       article.Version,
+		Tags:          article.Tags,
+		Category:      article.Category,
+		Status:        statusPb(article),
+		PublishAt:     publishAtOf(article),
+		Created:       article.Created.Format(time.RFC3339),
+		Updated:       article.Updated.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"grpc_web_log/web_log/web_log_pb"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchStream is a WatchArticles server stream passing the events to a channel
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *web_log_pb.ArticleEvent
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(event *web_log_pb.ArticleEvent) error {
+	s.events <- event
+	return nil
+}
+
+func nextEvent(t *testing.T, events <-chan *web_log_pb.ArticleEvent) *web_log_pb.ArticleEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return nil
+}
+
+func TestParseCursor(t *testing.T) {
+	h := &watchHub{run: "run", seq: 5}
+	for cursor, want := range map[string]codes.Code{
+		"run-3":     codes.OK,
+		"run-5":     codes.OK,
+		"run-6":     codes.InvalidArgument,
+		"earlier-3": codes.OutOfRange,
+		"run-x":     codes.InvalidArgument,
+		"nodash":    codes.InvalidArgument,
+	} {
+		if _, err := h.parseCursor(cursor); status.Code(err) != want {
+			t.Errorf("parseCursor(%q) returned %v, want %v", cursor, err, want)
+		}
+	}
+}
+
+func TestExternalEditPublishesEvents(t *testing.T) {
+	dir := t.TempDir()
+	st := openTestStore(t, dir)
+	defer abandon(st)
+	ctx := context.Background()
+	if err := st.add(ctx, Article{ArticleID: "a", Title: "a"}, Article{ArticleID: "b", Title: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	st.mu.Lock()
+	err := st.snapshot(ctx)
+	st.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, _, _, err := watchers.subscribe("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watchers.unsubscribe(w)
+	// someone else updates a, removes b and adds c
+	data, _ := json.Marshal([]Article{{ArticleID: "a", Title: "edited"}, {ArticleID: "c", Title: "c"}})
+	if err := os.WriteFile(filepath.Join(dir, "saveArticles.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	st.all(ctx)
+
+	got := make(map[string]string)
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-w.events:
+			res := watchFilter{editor: true}.event(event)
+			got[res.GetArticleID()] = res.GetType().String()
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got events %v, want 3", got)
+		}
+	}
+	want := map[string]string{"a": "UPDATED", "b": "DELETED", "c": "CREATED"}
+	for articleID, eventType := range want {
+		if got[articleID] != eventType {
+			t.Errorf("got events %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestWatchHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	st := openTestStore(t, dir)
+	defer abandon(st)
+	store = st
+	interval := watchHeartbeatInterval
+	watchHeartbeatInterval = 10 * time.Millisecond
+	defer func() { watchHeartbeatInterval = interval }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchStream{ctx: ctx, events: make(chan *web_log_pb.ArticleEvent, 16)}
+	done := make(chan error, 1)
+	go func() {
+		done <- (&server{}).WatchArticles(&web_log_pb.WatchArticlesRequest{Tags: []string{"watched"}}, stream)
+	}()
+	// the first heartbeat gives the watcher a cursor
+	if event := nextEvent(t, stream.events); event.GetType() != web_log_pb.ArticleEventType_HEARTBEAT {
+		t.Fatalf("got %v, want a heartbeat", event)
+	}
+
+	// the event of an article the watcher does not watch only moves its cursor
+	if err := st.add(ctx, Article{ArticleID: "other", Title: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	watchers.mu.Lock()
+	cursor := watchers.cursorOf(watchers.seq)
+	watchers.mu.Unlock()
+	event := nextEvent(t, stream.events)
+	if event.GetType() != web_log_pb.ArticleEventType_HEARTBEAT || event.GetCursor() != cursor || event.GetArticleID() != "" {
+		t.Errorf("got %v, want a heartbeat with cursor %s", event, cursor)
+	}
+	cancel()
+	<-done
+}
@@ -7,70 +7,133 @@ import (
 	"encoding/json"
 	"fmt"
 	"grpc_web_log/web_log/web_log_pb"
+	"grpc_web_log/webarticle"
 	"grpc_web_log/weblogger"
 	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type configuration struct {
-	Port string `json:"port"`
+	Port          string `json:"port"`
+	LogLevel      string `json:"logLevel"`
+	AccessLogPath string `json:"accessLogPath"`
+	ErrorLogPath  string `json:"errorLogPath"`
+	// text or json lines in the log files and stdout sinks
+	LogFormat string `json:"logFormat"`
+	// level name -> log file for the lines of that level, instead of errorLogPath
+	LevelLogPaths map[string]string `json:"levelLogPaths"`
+	StorePath     string            `json:"storePath"`
+	WALPath       string            `json:"walPath"`
+	CommentsPath  string            `json:"commentsPath"`
+	Auth          authConfig        `json:"auth"`
+	Limits        limitsConfig      `json:"limits"`
+	Metrics       metricsConfig     `json:"metrics"`
+	Tracing       tracingConfig     `json:"tracing"`
+	Gateway       gatewayConfig     `json:"gateway"`
+	GRPCWeb       grpcWebConfig     `json:"grpcWeb"`
+	Admin         adminConfig       `json:"admin"`
+	Feeds         feedsConfig       `json:"feeds"`
+	// seconds between two article store health checks
+	HealthCheckInterval int `json:"healthCheckInterval"`
+	// seconds between two checks for scheduled articles due to be published
+	PublishInterval int  `json:"publishInterval"`
+	Reflection      bool `json:"reflection"`
+	// seconds to let in-flight RPCs finish on SIGINT or SIGTERM
+	ShutdownTimeout int         `json:"shutdownTimeout"`
+	Store           storeConfig `json:"store"`
+	// extra log outputs besides the log files
+	LogSinks  []logSinkConfig `json:"logSinks"`
+	AccessLog accessLogConfig `json:"accessLog"`
 }
 
-type server struct{}
+type server struct {
+	web_log_pb.UnimplementedWebLogServiceServer
+}
 
-// Article is a single article
-/*
-struct fields must be exported (begins with a capital letter)
-or they won't be encoded
-*/
-type Article struct {
-	ArticleID string `json:"articleID"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
+type commentServer struct {
+	web_log_pb.UnimplementedCommentServiceServer
 }
 
+// Article is a single article, shared with the weblog tool
+type Article = webarticle.Article
+
 // Articles is a slice with multiple articles
 type Articles []Article
 
-// file path
+// default file path
 const (
 	accessLogFilePath = "logger/access.log"
 	errorLogFilePath  = "logger/error.log"
 	savedJSONFile     = "conf/saveArticles.json"
-	confFile          = "conf/conf.json"
+	walFile           = "conf/saveArticles.wal"
 )
 
+// config file, set by -config or WEBLOG_CONFIG
+var confFile = "conf/conf.json"
+
+// true when -config or WEBLOG_CONFIG names the config file, it must then exist
+var confFileGiven bool
+
 // web loggers
 var (
 	accessWebLogger weblogger.Weblogger
 	errorWebLogger  weblogger.Weblogger
 )
 
+// articles saved in saveArticles.json, cached in memory
+var store *articleStore
+
 // Get environment variables from json file
 func (config *configuration) getEnvVariables() error {
 	// method 1: Decode json file
 	file, err := os.Open(confFile)
-	if err != nil {
+	if os.IsNotExist(err) && !confFileGiven {
+		// without conf.json the defaults are used
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ClientIP = "ClientIP is NOT existed."
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Open config file error.", err)
-		return err
+		errorWebLogger.Info(getCurrentRPCmethod(pc), "No config file, using the defaults.", confFile)
+	} else {
+		if err != nil {
+			pc, _, _, _ := runtime.Caller(0)
+			errorWebLogger.Error(getCurrentRPCmethod(pc), "Open config file error.", err)
+			return err
+		}
+		defer file.Close()
+		decoderErr := config.decodeFile(file, filepath.Dir(confFile))
+		if decoderErr != nil {
+			pc, _, _, _ := runtime.Caller(0)
+			errorWebLogger.Error(getCurrentRPCmethod(pc), "Decode config file error.", decoderErr)
+			return decoderErr
+		}
+	}
+	// environment variables and flags override conf.json
+	overrideErr := config.applyOverrides()
+	if overrideErr != nil {
+		pc, _, _, _ := runtime.Caller(0)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Invalid config override.", overrideErr)
+		return overrideErr
 	}
-	decoder := json.NewDecoder(file)
-	decoderErr := decoder.Decode(&config)
-	if decoderErr != nil {
+	validateErr := config.validate()
+	if validateErr != nil {
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ClientIP = "ClientIP is NOT existed."
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Decode config file error.", decoderErr)
-		return decoderErr
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Invalid config file.", validateErr)
+		return validateErr
 	}
 
 	// method 2: Unmarshal json file
@@ -85,6 +148,11 @@ func (config *configuration) getEnvVariables() error {
 	return nil
 }
 
+// Get the error logger of a request, with its client IP and trace ID
+func errorLogOf(ctx context.Context) *weblogger.Entry {
+	return errorWebLogger.With(getClientIP(ctx), getPrincipal(ctx), getTraceID(ctx))
+}
+
 // Get gRPC client IP address for weblogger
 func getClientIP(ctx context.Context) string {
 	/* method 1: using peer package get ip address */
@@ -138,7 +206,7 @@ func generateUUID() string {
 	_, err := rand.Read(b)
 	if err != nil {
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Generate UUID error.", err)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Generate UUID error.", err)
 	}
 	// The slice should now contain random bytes
 	uuid := fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
@@ -146,101 +214,161 @@ func generateUUID() string {
 }
 
 // Read saved articles from saveArticles.json file which had saved articles from the client and return json-encoded data
-func getJSONData() []byte {
+func getJSONData(ctx context.Context, jsonFilePath string) []byte {
+	defer observeStore("read")()
+	_, span := tracer.Start(ctx, "getJSONData")
+	defer span.End()
 	// if the json file is not existed, create a new file
-	if _, err := os.Stat(savedJSONFile); os.IsNotExist(err) {
-		os.Create(savedJSONFile)
+	if _, err := os.Stat(jsonFilePath); os.IsNotExist(err) {
+		os.Create(jsonFilePath)
 		// it is necessary to have a object in json file or it will raise error
 		wtiteBrackets := []byte("[]")
-		writeErr := ioutil.WriteFile(savedJSONFile, wtiteBrackets, 0644)
+		writeErr := ioutil.WriteFile(jsonFilePath, wtiteBrackets, 0644)
 		if writeErr != nil {
 			pc, _, _, _ := runtime.Caller(0)
-			errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Write to json file error.", writeErr)
+			errorWebLogger.Error(getCurrentRPCmethod(pc), "Write to json file error.", writeErr)
 		}
 	}
 
-	jsonData, err := ioutil.ReadFile(savedJSONFile)
+	jsonData, err := ioutil.ReadFile(jsonFilePath)
 	if err != nil {
+		span.RecordError(err)
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Write to json file error.", err)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Read json file error.", err)
 	}
 	return jsonData
 }
 
 // Decode json data and store in currentArticles struct
-func getCurrentArticles(jsonData []byte) Articles {
+func getCurrentArticles(ctx context.Context, jsonData []byte) Articles {
+	_, span := tracer.Start(ctx, "getCurrentArticles")
+	defer span.End()
 	var currentArticles Articles
 	unmarshalErr := json.Unmarshal(jsonData, &currentArticles)
 	if unmarshalErr != nil {
+		span.RecordError(unmarshalErr)
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Umarshal json file error.", unmarshalErr)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Umarshal json file error.", unmarshalErr)
 	}
+	articlesStored.Set(float64(len(currentArticles)))
 	return currentArticles
 }
 
 // Write currentArticles struct to json file
-func (currentArticles *Articles) write2jsonFile() {
+// the articles are written to a temp file which replaces the json file, so a crash never leaves it half written
+func (currentArticles *Articles) write2jsonFile(ctx context.Context, jsonFilePath string) error {
+	defer observeStore("write")()
+	_, span := tracer.Start(ctx, "write2jsonFile")
+	defer span.End()
 	jsonFile, _ := json.MarshalIndent(&currentArticles, "", "  ")
-	// Permissions: 1 – execute, 2 – write, 4 – read
-	writeErr := ioutil.WriteFile(savedJSONFile, jsonFile, 0644)
+	writeErr := writeFileSync(jsonFilePath+".tmp", jsonFile)
+	if writeErr == nil {
+		writeErr = os.Rename(jsonFilePath+".tmp", jsonFilePath)
+	}
+	if writeErr == nil {
+		// the rename is only durable once the directory is synced
+		writeErr = syncDir(jsonFilePath)
+	}
 	if writeErr != nil {
+		span.RecordError(writeErr)
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Write to json file error.", writeErr)
+		errorWebLogger.Error(getCurrentRPCmethod(pc), "Write to json file error.", writeErr)
+		return writeErr
 	}
+	return nil
 }
 
-// Check whether if request ID is existed
-func isIDExist(currentArticles Articles, reqID string) (bool, string) {
-	for idx, article := range currentArticles {
-		if article.ArticleID == reqID {
-			return true, strconv.Itoa(idx)
-		}
+// Write data to a file and fsync it
+func writeFileSync(filePath string, data []byte) error {
+	// Permissions: 1 – execute, 2 – write, 4 – read
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Fsync the directory of a file, so a rename into it survives a crash
+func syncDir(filePath string) error {
+	dir, err := os.Open(filepath.Dir(filePath))
+	if err != nil {
+		return err
+	}
+	err = dir.Sync()
+	if closeErr := dir.Close(); err == nil {
+		err = closeErr
 	}
-	return false, "not exist"
+	return err
 }
 
 // gRPC service for SaveAllArticles
 func (*server) SaveAllArticles(stream web_log_pb.WebLogService_SaveAllArticlesServer) error {
-	fmt.Println("SaveAllArticles function was invoked with a streaming request")
-	accessWebLogger.ClientIP = getClientIP(stream.Context())
-	errorWebLogger.ClientIP = getClientIP(stream.Context())
+	errorLog := errorLogOf(stream.Context())
 	// return pc, filename, line, ok
 	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with a streaming request")
 
-	jsonData := getJSONData()
-	// current articles in the json file
-	currentArticles := getCurrentArticles(jsonData)
-
+	var newArticles Articles
 	var readArticles bytes.Buffer // save readed articles as string buffer
-	var logBuffer bytes.Buffer
+	var includeTags []string      // tag filter of the import
 	for {
 		req, err := stream.Recv()
+		if req != nil && len(req.GetIncludeTags()) != 0 {
+			includeTags = append(includeTags, req.GetIncludeTags()...)
+		}
 		// req == nil and len(req.String()) != 0
 		// read empty file will send empty_req but empty_req != nil but len(empty_req.String()) == 0
-		if req != nil && len(req.String()) != 0 {
+		// a request with only includeTags carries no article
+		if req != nil && req.GetArticle() != "" {
 			s := strings.Split(req.GetArticle(), "\n")
 			articleID := generateUUID()
 			// setup value for each field in Article struct
-			inputArticle := Article{ArticleID: articleID, Title: s[0], Content: s[1]}
+			inputArticle := Article{ArticleID: articleID, Title: s[0], Content: s[1], ContentFormat: req.GetContentFormat()}
+			if err := checkContentFormat(inputArticle.ContentFormat); err != nil {
+				return err
+			}
+			if err := classify(&inputArticle, req.GetTags(), req.GetCategory()); err != nil {
+				return err
+			}
+			if err := setInitialStatus(&inputArticle, req.GetStatus(), req.GetPublishAt()); err != nil {
+				return err
+			}
 			readArticles.WriteString("articleID: " + articleID + "\n")
 			readArticles.WriteString("title: " + s[0] + "\n\n")
-			currentArticles = append(currentArticles, inputArticle)
-			logBuffer.WriteString(req.GetArticle())
+			newArticles = append(newArticles, inputArticle)
 		}
 
 		var result bytes.Buffer // server response
 		if err == io.EOF {
+			skipped := 0
+			if len(includeTags) != 0 {
+				newArticles, skipped = filterTags(newArticles, includeTags)
+			}
 			if len(readArticles.String()) == 0 {
 				pc, _, _, _ := runtime.Caller(0)
-				errorWebLogger.ErrorPrintln(getCurrentRPCmethod(pc), "It is an empty file.")
+				errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "It is an empty file.")
 				result.WriteString("It is an empty file. NO new article is saved")
+			} else if skipped != 0 {
+				result.WriteString(strconv.Itoa(len(newArticles)) + " new articles have been saved, " +
+					strconv.Itoa(skipped) + " without the included tags were skipped")
 			} else {
 				result.WriteString("All new articles have been saved")
 			}
-			accessWebLogger.AccessPrintln(getCurrentRPCmethod(pc), logBuffer.String())
+			setAccessPayload(stream.Context(), accessPayload.format(newArticles...))
 
 			// Save json file
-			currentArticles.write2jsonFile()
+			if len(newArticles) != 0 {
+				if err := store.add(stream.Context(), newArticles...); err != nil {
+					return status.Error(codes.Internal, "articles could not be saved")
+				}
+			}
 			return stream.SendAndClose(
 				&web_log_pb.SaveAllArticlesResponse{
 					Result: result.String(),
@@ -248,26 +376,25 @@ func (*server) SaveAllArticles(stream web_log_pb.WebLogService_SaveAllArticlesSe
 		}
 		if err != nil {
 			pc, _, _, _ := runtime.Caller(0)
-			errorWebLogger.FatalPrintln(getCurrentRPCmethod(pc), "Error while reading client stream.", err)
+			errorLog.Error(getCurrentRPCmethod(pc), "Error while reading client stream.", err)
+			return err
 		}
 	}
 }
 
 // gRPC service for GetAllArticles
 func (*server) GetAllArticles(ctx context.Context, req *web_log_pb.GetAllArticlesRequest) (*web_log_pb.GetAllArticlesResponse, error) {
-	fmt.Println("GetArticles function was invoked with a streaming request")
-	accessWebLogger.ClientIP = getClientIP(ctx)
-	errorWebLogger.ClientIP = getClientIP(ctx)
+	errorLog := errorLogOf(ctx)
 	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with a streaming request")
 
-	jsonData := getJSONData()
-	// current articles in the json file
-	currentArticles := getCurrentArticles(jsonData)
+	// current articles in the json file, readers only see the published ones
+	currentArticles := visibleArticles(ctx, store.all(ctx))
 
 	var result bytes.Buffer // server response (using string buffer to concate strings)
 	if len(currentArticles) == 0 {
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ErrorPrintln(getCurrentRPCmethod(pc), "No article is available now.")
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "No article is available now.")
 		result.WriteString("No article is available now.")
 	} else {
 		for _, article := range currentArticles {
@@ -278,35 +405,29 @@ func (*server) GetAllArticles(ctx context.Context, req *web_log_pb.GetAllArticle
 	res := &web_log_pb.GetAllArticlesResponse{
 		Result: result.String(),
 	}
-	accessWebLogger.AccessPrintln(getCurrentRPCmethod(pc), "")
 	return res, nil
 }
 
 // gRPC service for GetSpecifiedArticle
 func (*server) GetSpecifiedArticle(ctx context.Context, req *web_log_pb.GetSpecifiedArticleRequest) (*web_log_pb.GetSpecifiedArticleResponse, error) {
-	fmt.Printf("GetSpecifiedArticle function was invoked with %v\n", req)
-	accessWebLogger.ClientIP = getClientIP(ctx)
-	errorWebLogger.ClientIP = getClientIP(ctx)
+	errorLog := errorLogOf(ctx)
 	pc, _, _, _ := runtime.Caller(0)
-
-	jsonData := getJSONData()
-
-	// current articles in the json file
-	currentArticles := getCurrentArticles(jsonData)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
 
 	title := ""
 	content := ""
-	isExist, idx := isIDExist(currentArticles, req.ArticleID)
-	i, _ := strconv.Atoi(idx)
+	article, isExist := store.get(ctx, req.ArticleID)
+	// an unpublished article does not exist for readers
+	isExist = isExist && canSeeArticle(ctx, article)
 
 	if isExist {
-		title = currentArticles[i].Title
-		content = currentArticles[i].Content
+		title = article.Title
+		content = article.Content
 	} else {
 		title = "title NOT exist"
 		content = "content NOT exist"
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
 	}
 
 	// Create response
@@ -315,37 +436,51 @@ func (*server) GetSpecifiedArticle(ctx context.Context, req *web_log_pb.GetSpeci
 		Title:     title,
 		Content:   content,
 	}
+	if isExist {
+		res.ContentFormat = contentFormatOf(article)
+		res.Version = article.Version
+		res.Tags = article.Tags
+		res.Category = article.Category
+		res.Status = statusPb(article)
+		res.PublishAt = publishAtOf(article)
+	}
 
-	var logBuffer bytes.Buffer
-	logBuffer.WriteString("articleID=" + req.GetArticleID())
-	accessWebLogger.AccessPrintln(getCurrentRPCmethod(pc), logBuffer.String())
+	setAccessPayload(ctx, "articleID="+req.GetArticleID())
 	return res, nil
 }
 
 // gRPC service for UpdateSpecifiedArticle
 func (*server) UpdateSpecifiedArticle(ctx context.Context, req *web_log_pb.UpdateSpecifiedArticleRequest) (*web_log_pb.UpdateSpecifiedArticleResponse, error) {
-	fmt.Printf("UpdateSpecifiedArticle function was invoked with %v\n", req)
-	accessWebLogger.ClientIP = getClientIP(ctx)
-	errorWebLogger.ClientIP = getClientIP(ctx)
+	errorLog := errorLogOf(ctx)
 	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
 
-	jsonData := getJSONData()
-	// current articles in the json file
-	currentArticles := getCurrentArticles(jsonData)
-
-	isExist, idx := isIDExist(currentArticles, req.ArticleID)
-	i, _ := strconv.Atoi(idx) // string to int
-
+	if err := checkContentFormat(req.GetContentFormat()); err != nil {
+		return nil, err
+	}
+	// only the fields set in the request are changed, a PATCH leaves out the others
+	change := setContent(req.Title, req.Content, req.ContentFormat)
+	if req.Category != nil {
+		category, err := normalizeCategory(req.GetCategory())
+		if err != nil {
+			return nil, err
+		}
+		change = func(article *Article) error {
+			article.Category = category
+			return setContent(req.Title, req.Content, req.ContentFormat)(article)
+		}
+	}
 	var result bytes.Buffer
+	// update and save json file
+	updated, isExist, err := store.update(ctx, req.ArticleID, change)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "article could not be updated")
+	}
 	if isExist {
-		currentArticles[i].Title = req.Title
-		currentArticles[i].Content = req.Content
-		// Save json file
-		currentArticles.write2jsonFile()
 		result.WriteString("The article with aricleID " + req.ArticleID + " has been updated")
 	} else {
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
 		result.WriteString("The article with aricleID " + req.ArticleID + " is NOT existed")
 	}
 
@@ -354,37 +489,32 @@ func (*server) UpdateSpecifiedArticle(ctx context.Context, req *web_log_pb.Updat
 		Result: result.String(),
 	}
 
-	var logBuffer bytes.Buffer
-	logBuffer.WriteString("articleID=" + req.GetArticleID())
-	accessWebLogger.AccessPrintln(getCurrentRPCmethod(pc), logBuffer.String())
+	setAccessPayload(ctx, accessPayload.format(Article{ArticleID: req.GetArticleID(), Title: updated.Title, Content: updated.Content}))
 	return res, nil
 }
 
 // gRPC service for RemoveSpecifiedArticle
 func (*server) RemoveSpecifiedArticle(ctx context.Context, req *web_log_pb.RemoveSpecifiedArticleRequest) (*web_log_pb.RemoveSpecifiedArticleResponse, error) {
-	fmt.Printf("RemoveSpecifiedArticle function was invoked with %v\n", req)
-	accessWebLogger.ClientIP = getClientIP(ctx)
-	errorWebLogger.ClientIP = getClientIP(ctx)
+	errorLog := errorLogOf(ctx)
 	pc, _, _, _ := runtime.Caller(0)
-
-	jsonData := getJSONData()
-	// current articles in the json file
-	currentArticles := getCurrentArticles(jsonData)
-
-	isExist, idx := isIDExist(currentArticles, req.ArticleID)
-	i, _ := strconv.Atoi(idx) // string to int
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
 
 	var result bytes.Buffer
+	_, isExist := store.get(ctx, req.ArticleID)
 	if isExist {
-		// Remove request article
-		currentArticles = append(currentArticles[:i], currentArticles[i+1:]...)
-		// Save json file
-		currentArticles.write2jsonFile()
+		// the comments go with the article, first so a failure does not leave them without it
+		if _, err := comments.removeArticle(ctx, req.ArticleID); err != nil {
+			return nil, status.Error(codes.Internal, "comments of the article could not be removed")
+		}
+		// remove request article and save json file
+		if _, err := store.remove(ctx, req.ArticleID); err != nil {
+			return nil, status.Error(codes.Internal, "article could not be removed")
+		}
 		result.WriteString("The article with articleID " + req.ArticleID + " has been removed")
 	} else {
 		result.WriteString("The article with articleID " + req.ArticleID + " is NOT existed")
 		pc, _, _, _ := runtime.Caller(0)
-		errorWebLogger.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
 	}
 
 	// Create response
@@ -392,28 +522,368 @@ func (*server) RemoveSpecifiedArticle(ctx context.Context, req *web_log_pb.Remov
 		Result: result.String(),
 	}
 
-	var logBuffer bytes.Buffer
-	logBuffer.WriteString("articleID=" + req.GetArticleID())
-	accessWebLogger.AccessPrintln(getCurrentRPCmethod(pc), logBuffer.String())
+	setAccessPayload(ctx, "articleID="+req.GetArticleID())
+	return res, nil
+}
+
+// gRPC service for RenderArticle
+func (*server) RenderArticle(ctx context.Context, req *web_log_pb.RenderArticleRequest) (*web_log_pb.RenderArticleResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "articleID="+req.GetArticleID())
+	article, isExist := store.get(ctx, req.ArticleID)
+	if !isExist || !canSeeArticle(ctx, article) {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	rendered, err := renders.get(article)
+	if err != nil {
+		errorLog.Error(getCurrentRPCmethod(pc), "Failed to render article.", err)
+		return nil, status.Error(codes.Internal, "article could not be rendered")
+	}
+
+	res := &web_log_pb.RenderArticleResponse{
+		ArticleID: article.ArticleID,
+		Title:     article.Title,
+		Html:      rendered.HTML,
+		Toc:       tocOf(rendered),
+		Excerpt:   rendered.Excerpt,
+		Version:   article.Version,
+	}
 	return res, nil
 }
 
+// gRPC service for AddTags
+func (*server) AddTags(ctx context.Context, req *web_log_pb.AddTagsRequest) (*web_log_pb.TagsResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	tags, err := normalizeTags(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	return changeTags(ctx, req.GetArticleID(), tags, addTags(tags))
+}
+
+// gRPC service for RemoveTags
+func (*server) RemoveTags(ctx context.Context, req *web_log_pb.RemoveTagsRequest) (*web_log_pb.TagsResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	tags, err := normalizeTags(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	return changeTags(ctx, req.GetArticleID(), tags, removeTags(tags))
+}
+
+// Apply a tag change of AddTags or RemoveTags
+func changeTags(ctx context.Context, articleID string, tags []string, change func(article *Article) error) (*web_log_pb.TagsResponse, error) {
+	setAccessPayload(ctx, "articleID="+articleID+" tags="+strconv.Quote(strings.Join(tags, ",")))
+	article, isExist, err := store.update(ctx, articleID, change)
+	if _, ok := status.FromError(err); err != nil && ok {
+		// refused by change
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "tags could not be changed")
+	}
+	if !isExist {
+		pc, _, _, _ := runtime.Caller(0)
+		errorLogOf(ctx).Error(getCurrentRPCmethod(pc), "articleID is NOT existed.", articleID)
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	return &web_log_pb.TagsResponse{ArticleID: article.ArticleID, Tags: article.Tags, Version: article.Version}, nil
+}
+
+// gRPC service for ListTags
+func (*server) ListTags(ctx context.Context, req *web_log_pb.ListTagsRequest) (*web_log_pb.ListTagsResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	tags, categories := countTags(visibleArticles(ctx, store.all(ctx)))
+	return &web_log_pb.ListTagsResponse{Tags: tags, Categories: categories}, nil
+}
+
+// gRPC service for ListArticles
+func (*server) ListArticles(ctx context.Context, req *web_log_pb.ListArticlesRequest) (*web_log_pb.ListArticlesResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	return listArticles(visibleArticles(ctx, store.all(ctx)), req)
+}
+
+// gRPC service for SetArticleStatus
+func (*server) SetArticleStatus(ctx context.Context, req *web_log_pb.SetArticleStatusRequest) (*web_log_pb.SetArticleStatusResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "articleID="+req.GetArticleID()+" status="+req.GetStatus().String())
+	to, err := articleStatusOf(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	article, isExist, err := store.update(ctx, req.GetArticleID(), setStatus(to, req.PublishAt))
+	if _, ok := status.FromError(err); err != nil && ok {
+		// refused by setStatus
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), status.Convert(err).Message())
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "status could not be changed")
+	}
+	if !isExist {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	return &web_log_pb.SetArticleStatusResponse{
+		ArticleID: article.ArticleID,
+		Status:    statusPb(article),
+		PublishAt: publishAtOf(article),
+		Version:   article.Version,
+	}, nil
+}
+
+// gRPC service for WatchArticles
+func (*server) WatchArticles(req *web_log_pb.WatchArticlesRequest, stream web_log_pb.WebLogService_WatchArticlesServer) error {
+	ctx := stream.Context()
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "tags="+strconv.Quote(strings.Join(req.GetTags(), ","))+
+		" articleIDs="+strconv.Quote(strings.Join(req.GetArticleIDs(), ","))+" cursor="+req.GetCursor())
+	w, missed, latest, err := watchers.subscribe(req.GetCursor())
+	if err != nil {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), status.Convert(err).Message())
+		return err
+	}
+	defer watchers.unsubscribe(w)
+
+	filter := newWatchFilter(ctx, req)
+	// latest is the cursor of the newest event seen, sent the cursor the watcher has
+	sent, skipped := req.GetCursor(), 0
+	send := func(event articleEvent) error {
+		latest = event.cursor
+		res := filter.event(event)
+		if res == nil {
+			if skipped++; skipped < watchBufferSize/2 {
+				return nil
+			}
+			res = heartbeatEvent(latest)
+		}
+		sent, skipped = latest, 0
+		return stream.Send(res)
+	}
+	for _, event := range missed {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-w.done:
+			return w.err
+		case event := <-w.events:
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if latest == sent {
+				continue
+			}
+			sent, skipped = latest, 0
+			if err := stream.Send(heartbeatEvent(latest)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// gRPC service for AddComment
+func (*commentServer) AddComment(ctx context.Context, req *web_log_pb.AddCommentRequest) (*web_log_pb.Comment, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "articleID="+req.GetArticleID()+" parentID="+req.GetParentID())
+	content, err := checkCommentContent(req.GetContent())
+	if err != nil {
+		return nil, err
+	}
+	if article, isExist := store.get(ctx, req.GetArticleID()); !isExist || !canSeeArticle(ctx, article) {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	comment := Comment{ArticleID: req.GetArticleID(), ParentID: req.GetParentID(), Author: getPrincipal(ctx), Content: content, Status: commentPending}
+	// comments of moderators need no moderation
+	if hasRole(ctx, roleEditor) {
+		comment.Status = commentApproved
+	}
+	comment, err = comments.add(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	return commentPb(comment), nil
+}
+
+// gRPC service for ListComments
+func (*commentServer) ListComments(ctx context.Context, req *web_log_pb.ListCommentsRequest) (*web_log_pb.ListCommentsResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "articleID="+req.GetArticleID())
+	if article, isExist := store.get(ctx, req.GetArticleID()); !isExist || !canSeeArticle(ctx, article) {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "articleID is NOT existed.")
+		return nil, status.Error(codes.NotFound, "articleID is NOT existed.")
+	}
+	return listComments(ctx, comments.forArticle(req.GetArticleID()), req)
+}
+
+// gRPC service for EditComment
+func (*commentServer) EditComment(ctx context.Context, req *web_log_pb.EditCommentRequest) (*web_log_pb.Comment, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "commentID="+req.GetCommentID())
+	content, err := checkCommentContent(req.GetContent())
+	if err != nil {
+		return nil, err
+	}
+	comment, err := comments.update(ctx, req.GetCommentID(), func(comment *Comment) error {
+		if !canChangeComment(ctx, *comment) {
+			return status.Error(codes.PermissionDenied, "only the author or an editor can edit a comment")
+		}
+		comment.Content = content
+		// an edited comment is moderated again
+		if !hasRole(ctx, roleEditor) {
+			comment.Status = commentPending
+		}
+		return nil
+	})
+	if err != nil {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), status.Convert(err).Message())
+		return nil, err
+	}
+	return commentPb(comment), nil
+}
+
+// gRPC service for ModerateComment
+func (*commentServer) ModerateComment(ctx context.Context, req *web_log_pb.ModerateCommentRequest) (*web_log_pb.Comment, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "commentID="+req.GetCommentID()+" status="+req.GetStatus().String())
+	moderation, err := moderationStatusOf(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	comment, err := comments.update(ctx, req.GetCommentID(), func(comment *Comment) error {
+		comment.Status = moderation
+		return nil
+	})
+	if err != nil {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), status.Convert(err).Message())
+		return nil, err
+	}
+	return commentPb(comment), nil
+}
+
+// gRPC service for DeleteComment
+func (*commentServer) DeleteComment(ctx context.Context, req *web_log_pb.DeleteCommentRequest) (*web_log_pb.DeleteCommentResponse, error) {
+	errorLog := errorLogOf(ctx)
+	pc, _, _, _ := runtime.Caller(0)
+	errorLog.Debug(getCurrentRPCmethod(pc), "invoked with", req)
+
+	setAccessPayload(ctx, "commentID="+req.GetCommentID())
+	comment, isExist := comments.get(req.GetCommentID())
+	if !isExist {
+		errorLog.ErrorPrintln(getCurrentRPCmethod(pc), "commentID is NOT existed.")
+		return nil, status.Error(codes.NotFound, "commentID is NOT existed.")
+	}
+	if !canChangeComment(ctx, comment) {
+		return nil, status.Error(codes.PermissionDenied, "only the author or an editor can delete a comment")
+	}
+	deleted, err := comments.remove(ctx, req.GetCommentID())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "comment could not be deleted")
+	}
+	return &web_log_pb.DeleteCommentResponse{Deleted: int32(deleted)}, nil
+}
+
 // main function
 func main() {
-	fmt.Println("Server(go) is on !")
+	// lines not written for a request, e.g. at startup or by the background loops
+	errorWebLogger.ClientIP = "ClientIP is NOT existed."
+	parseFlags()
 	// read conf.json file
-	config := configuration{}
+	config := defaultConfiguration()
 	readConfigErr := config.getEnvVariables()
 
 	if readConfigErr != nil {
-		errorWebLogger.ClientIP = "Client IP is NOT existed."
 		errorWebLogger.ServerFatalPrintln("Failed to read config file.", readConfigErr)
 	}
+	if *printConfig {
+		config.print()
+		return
+	}
+
+	fmt.Println("Server(go) is on !")
 	// init weblogger
-	accessWebLogger.InitWebLogger(accessLogFilePath)
-	errorWebLogger.InitWebLogger(errorLogFilePath)
+	initLogFiles(config)
+	setLogLevel(config.LogLevel)
+	accessPayload.setConfig(config.AccessLog)
+	if err := routeLogLevels(config.LevelLogPaths, config.LogFormat); err != nil {
+		errorWebLogger.ServerFatalPrintln("Failed to open level log file.", err)
+	}
+	if err := addLogSinks(config.LogSinks, config.LogFormat); err != nil {
+		errorWebLogger.ServerFatalPrintln("Failed to open log sink.", err)
+	}
+	accessWebLogger.OnWriteError = countLogWriteFailure("access")
+	errorWebLogger.OnWriteError = countLogWriteFailure("error")
+
+	// HTTP listeners, shut down before the gRPC server
+	var httpServers []*http.Server
+	if config.Metrics.Enabled {
+		httpServers = append(httpServers, serveMetrics(config.Metrics))
+	}
+	if config.Gateway.Enabled {
+		httpServers = append(httpServers, serveGateway(config.Gateway, config.Port))
+	}
 
-	lis, err := net.Listen("tcp4", "0.0.0.0:"+config.Port)
+	shutdownTracing, err := initTracing(config.Tracing)
+	if err != nil {
+		errorWebLogger.ServerFatalPrintln("Failed to init tracing.", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	store, err = openArticleStore(context.Background(), config.StorePath, config.WALPath, config.Store)
+	if err != nil {
+		errorWebLogger.ServerFatalPrintln("Failed to open article store.", err)
+	}
+	loops := newBackgroundLoops()
+	loops.run(store.compactLoop)
+	loops.run(func(stop <-chan struct{}) { publishLoop(config.PublishInterval, stop) })
+	comments, err = openCommentStore(config.CommentsPath)
+	if err != nil {
+		errorWebLogger.ServerFatalPrintln("Failed to open comment store.", err)
+	}
+
+	lis, err := net.Listen("tcp4", config.grpcAddr())
 
 	// another way to get port
 	// port := "0.0.0.0:" + os.Getenv("port")
@@ -423,15 +893,47 @@ func main() {
 		errorWebLogger.ServerFatalPrintln("Failed to listen.", err)
 	}
 
-	s := grpc.NewServer()
+	authn := newAuthenticator(config.Auth)
+	limiter := newRateLimiter(config.Limits)
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, accessUnaryInterceptor, authn.unaryInterceptor, limiter.unaryInterceptor),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor, accessStreamInterceptor, authn.streamInterceptor, limiter.streamInterceptor),
+	}
+	opts = append(opts, config.Limits.serverOptions()...)
+	s := grpc.NewServer(opts...)
 	web_log_pb.RegisterWebLogServiceServer(s, &server{})
+	web_log_pb.RegisterCommentServiceServer(s, &commentServer{})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go watchStoreHealth(healthServer, config.StorePath, config.HealthCheckInterval)
 
-	if err := s.Serve(lis); err != nil {
-		errorWebLogger.ServerFatalPrintln("Failed to serve.", err)
+	if config.Reflection {
+		reflection.Register(s)
+	}
+	if config.GRPCWeb.Enabled {
+		httpServers = append(httpServers, serveGRPCWeb(s, config.GRPCWeb))
 	}
+	if config.Admin.Enabled {
+		httpServers = append(httpServers, serveAdmin(config.Admin, authn))
+	}
+	if config.Feeds.Enabled {
+		httpServers = append(httpServers, serveFeeds(config.Feeds))
+	}
+
+	// reload conf.json on SIGHUP or file change
+	go watchConfig(config, authn, limiter)
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			errorWebLogger.ServerFatalPrintln("Failed to serve.", err)
+		}
+	}()
+	waitForShutdown(s, healthServer, httpServers, loops, config.ShutdownTimeout)
 
-	// Disable logger
-	// ioutil.Discard: a writer on which all calls succeed without doing anything
-	accessWebLogger.Logger.SetOutput(ioutil.Discard)
-	errorWebLogger.Logger.SetOutput(ioutil.Discard)
+	// Flush and close the log files
+	accessWebLogger.Close()
+	errorWebLogger.Close()
+	fmt.Println("Server(go) is off !")
 }
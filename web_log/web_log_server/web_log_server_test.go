@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateSpecifiedArticleChangesOnlySetFields(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := context.Background()
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "title", Content: "# content", ContentFormat: "markdown", Category: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	s := &server{}
+
+	if _, err := s.UpdateSpecifiedArticle(ctx, &web_log_pb.UpdateSpecifiedArticleRequest{ArticleID: "a", Title: proto.String("new title")}); err != nil {
+		t.Fatal(err)
+	}
+	article, _ := store.get(ctx, "a")
+	if article.Title != "new title" || article.Content != "# content" || article.ContentFormat != "markdown" || article.Category != "go" {
+		t.Errorf("updating the title gave %+v", article)
+	}
+
+	if _, err := s.UpdateSpecifiedArticle(ctx, &web_log_pb.UpdateSpecifiedArticleRequest{ArticleID: "a", Content: proto.String(""), ContentFormat: proto.String("plain")}); err != nil {
+		t.Fatal(err)
+	}
+	article, _ = store.get(ctx, "a")
+	if article.Title != "new title" || article.Content != "" || article.ContentFormat != "plain" || article.Version != 3 {
+		t.Errorf("clearing the content gave %+v", article)
+	}
+}
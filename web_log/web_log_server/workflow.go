@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"grpc_web_log/web_log/web_log_pb"
+	"runtime"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// status of an article in saveArticles.json, empty for articles saved before articles had a status
+const (
+	statusDraft     = "draft"
+	statusInReview  = "in_review"
+	statusPublished = "published"
+	statusArchived  = "archived"
+)
+
+// default seconds between two checks for scheduled articles
+const defaultPublishInterval = 30
+
+// statuses an article can move to from each status
+var statusTransitions = map[string][]string{
+	statusDraft:     {statusInReview, statusPublished},
+	statusInReview:  {statusDraft, statusPublished},
+	statusPublished: {statusDraft, statusArchived},
+	statusArchived:  {statusDraft, statusPublished},
+}
+
+// errNotDue tells store.update that a scheduled article was changed before it became due
+var errNotDue = errors.New("article is not due")
+
+// Get the status of an article, published for articles saved without one
+func statusOf(article Article) string {
+	if article.Status == "" {
+		return statusPublished
+	}
+	return article.Status
+}
+
+// Get the status of a request, empty when unspecified
+func articleStatusOf(s web_log_pb.ArticleStatus) (string, error) {
+	switch s {
+	case web_log_pb.ArticleStatus_ARTICLE_STATUS_UNSPECIFIED:
+		return "", nil
+	case web_log_pb.ArticleStatus_DRAFT:
+		return statusDraft, nil
+	case web_log_pb.ArticleStatus_IN_REVIEW:
+		return statusInReview, nil
+	case web_log_pb.ArticleStatus_PUBLISHED:
+		return statusPublished, nil
+	case web_log_pb.ArticleStatus_ARCHIVED:
+		return statusArchived, nil
+	}
+	return "", status.Error(codes.InvalidArgument, "status "+s.String()+" is not draft, in_review, published or archived")
+}
+
+// Get the status of an article for a response
+func statusPb(article Article) web_log_pb.ArticleStatus {
+	switch statusOf(article) {
+	case statusDraft:
+		return web_log_pb.ArticleStatus_DRAFT
+	case statusInReview:
+		return web_log_pb.ArticleStatus_IN_REVIEW
+	case statusArchived:
+		return web_log_pb.ArticleStatus_ARCHIVED
+	}
+	return web_log_pb.ArticleStatus_PUBLISHED
+}
+
+// Get the publish time of an article for a response, empty when it is not scheduled
+func publishAtOf(article Article) string {
+	if article.PublishAt == nil {
+		return ""
+	}
+	return article.PublishAt.UTC().Format(time.RFC3339)
+}
+
+// Parse the publishAt of a request, nil when empty
+func parsePublishAt(publishAt string) (*time.Time, error) {
+	if publishAt == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, publishAt)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "publishAt "+strconv.Quote(publishAt)+" is not an RFC 3339 time")
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// Only drafts and articles in review wait for their publish time
+func checkSchedule(article Article) error {
+	if article.PublishAt != nil && statusOf(article) != statusDraft && statusOf(article) != statusInReview {
+		return status.Error(codes.FailedPrecondition, "only a draft or in_review article can be scheduled")
+	}
+	return nil
+}
+
+// Set the status and publish time of a new article
+func setInitialStatus(article *Article, s web_log_pb.ArticleStatus, publishAt string) error {
+	var err error
+	if article.Status, err = articleStatusOf(s); err != nil {
+		return err
+	}
+	if article.PublishAt, err = parsePublishAt(publishAt); err != nil {
+		return err
+	}
+	if article.Status == "" {
+		article.Status = statusPublished
+		if article.PublishAt != nil {
+			article.Status = statusDraft
+		}
+	}
+	return checkSchedule(*article)
+}
+
+// Move an article to another status and, when publishAt is not nil, schedule or unschedule it
+func setStatus(to string, publishAt *string) func(article *Article) error {
+	return func(article *Article) error {
+		from := statusOf(*article)
+		if to != "" && to != from {
+			allowed := false
+			for _, next := range statusTransitions[from] {
+				allowed = allowed || next == to
+			}
+			if !allowed {
+				return status.Error(codes.FailedPrecondition, "an article cannot go from "+from+" to "+to)
+			}
+			article.Status = to
+		}
+		if publishAt != nil {
+			t, err := parsePublishAt(*publishAt)
+			if err != nil {
+				return err
+			}
+			article.PublishAt = t
+		} else if to == statusPublished || to == statusArchived {
+			// published by hand before its time
+			article.PublishAt = nil
+		}
+		return checkSchedule(*article)
+	}
+}
+
+// Check whether the caller sees an article: editors see every article, readers the published ones
+func canSeeArticle(ctx context.Context, article Article) bool {
+	return statusOf(article) == statusPublished || hasRole(ctx, roleEditor)
+}
+
+// Keep the articles the caller sees
+func visibleArticles(ctx context.Context, articles Articles) Articles {
+	if hasRole(ctx, roleEditor) {
+		return articles
+	}
+	return publishedArticles(articles)
+}
+
+// Keep the published articles
+func publishedArticles(articles Articles) Articles {
+	var published Articles
+	for _, article := range articles {
+		if statusOf(article) == statusPublished {
+			published = append(published, article)
+		}
+	}
+	return published
+}
+
+// Check whether a scheduled article is due
+func isDue(article Article, now time.Time) bool {
+	s := statusOf(article)
+	return article.PublishAt != nil && !article.PublishAt.After(now) && (s == statusDraft || s == statusInReview)
+}
+
+// Publish the scheduled articles that are due, every interval seconds until stop is closed
+func publishLoop(interval int, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = defaultPublishInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			publishDue(context.Background())
+		}
+	}
+}
+
+// Publish the scheduled articles that are due
+func publishDue(ctx context.Context) {
+	now := time.Now()
+	for _, article := range store.all(ctx) {
+		if !isDue(article, now) {
+			continue
+		}
+		_, _, err := store.update(ctx, article.ArticleID, func(article *Article) error {
+			// the article may have changed since store.all
+			if !isDue(*article, now) {
+				return errNotDue
+			}
+			article.Status = statusPublished
+			article.PublishAt = nil
+			return nil
+		})
+		pc, _, _, _ := runtime.Caller(0)
+		if err != nil && err != errNotDue {
+			errorWebLogger.Error(getCurrentRPCmethod(pc), "Failed to publish scheduled article.", article.ArticleID, err)
+		} else if err == nil {
+			errorWebLogger.Info(getCurrentRPCmethod(pc), "Published scheduled article.", article.ArticleID)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Get a context of a caller with the role
+func asRole(role string) context.Context {
+	return context.WithValue(context.Background(), principalKey{}, principal{Name: role, Role: role})
+}
+
+func TestStatusTransitions(t *testing.T) {
+	all := []string{statusDraft, statusInReview, statusPublished, statusArchived}
+	allowed := map[string]bool{
+		"draft>in_review": true, "draft>published": true,
+		"in_review>draft": true, "in_review>published": true,
+		"published>draft": true, "published>archived": true,
+		"archived>draft": true, "archived>published": true,
+	}
+	for _, from := range all {
+		for _, to := range all {
+			article := Article{Status: from}
+			err := setStatus(to, nil)(&article)
+			switch {
+			case from == to || allowed[from+">"+to]:
+				if err != nil || article.Status != to {
+					t.Errorf("%s to %s returned %v with status %s", from, to, err, article.Status)
+				}
+			case status.Code(err) != codes.FailedPrecondition:
+				t.Errorf("%s to %s returned %v, want FailedPrecondition", from, to, err)
+			}
+		}
+	}
+
+	// a published article cannot be scheduled
+	publishAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	article := Article{Status: statusPublished}
+	if err := setStatus("", &publishAt)(&article); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("scheduling a published article returned %v, want FailedPrecondition", err)
+	}
+}
+
+func TestSetArticleStatusRefusedKeepsVersion(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := asRole(roleEditor)
+	if err := store.add(ctx, Article{ArticleID: "a", Title: "a", Content: "a", Status: statusDraft}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := (&server{}).SetArticleStatus(ctx, &web_log_pb.SetArticleStatusRequest{ArticleID: "a", Status: web_log_pb.ArticleStatus_ARCHIVED})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("draft to archived returned %v, want FailedPrecondition", err)
+	}
+	if article, _ := store.get(ctx, "a"); article.Status != statusDraft || article.Version != 1 {
+		t.Errorf("refused change left %s at version %d", article.Status, article.Version)
+	}
+
+	res, err := (&server{}).SetArticleStatus(ctx, &web_log_pb.SetArticleStatusRequest{ArticleID: "a", Status: web_log_pb.ArticleStatus_IN_REVIEW})
+	if err != nil || res.GetStatus() != web_log_pb.ArticleStatus_IN_REVIEW || res.GetVersion() != 2 {
+		t.Errorf("draft to in_review returned %v, %v", res, err)
+	}
+}
+
+func TestPublishDue(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	ctx := context.Background()
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	if err := store.add(ctx,
+		Article{ArticleID: "due", Title: "due", Content: "due", Status: statusDraft, PublishAt: &past},
+		Article{ArticleID: "review", Title: "review", Content: "review", Status: statusInReview, PublishAt: &past},
+		Article{ArticleID: "later", Title: "later", Content: "later", Status: statusDraft, PublishAt: &future},
+		Article{ArticleID: "draft", Title: "draft", Content: "draft", Status: statusDraft},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	publishDue(ctx)
+	for articleID, want := range map[string]string{"due": statusPublished, "review": statusPublished, "later": statusDraft, "draft": statusDraft} {
+		article, _ := store.get(ctx, articleID)
+		if article.Status != want {
+			t.Errorf("%s is %s, want %s", articleID, article.Status, want)
+		}
+		if want == statusPublished && article.PublishAt != nil {
+			t.Errorf("%s is still scheduled after publishing", articleID)
+		}
+	}
+}
+
+func TestReadersDoNotSeeDrafts(t *testing.T) {
+	st := openTestStore(t, t.TempDir())
+	defer abandon(st)
+	store = st
+	if err := store.add(context.Background(),
+		Article{ArticleID: "published", Title: "published title", Content: "published", Status: statusPublished},
+		Article{ArticleID: "draft", Title: "draft title", Content: "draft", Status: statusDraft},
+		Article{ArticleID: "review", Title: "review title", Content: "review", Status: statusInReview},
+		Article{ArticleID: "archived", Title: "archived title", Content: "archived", Status: statusArchived},
+	); err != nil {
+		t.Fatal(err)
+	}
+	s := &server{}
+
+	for _, test := range []struct {
+		role string
+		sees map[string]bool
+	}{
+		{roleReader, map[string]bool{"published": true}},
+		{roleEditor, map[string]bool{"published": true, "draft": true, "review": true, "archived": true}},
+		{roleAdmin, map[string]bool{"published": true, "draft": true, "review": true, "archived": true}},
+	} {
+		ctx := asRole(test.role)
+		for _, articleID := range []string{"published", "draft", "review", "archived"} {
+			res, err := s.GetSpecifiedArticle(ctx, &web_log_pb.GetSpecifiedArticleRequest{ArticleID: articleID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := res.GetTitle() == articleID+" title"; got != test.sees[articleID] {
+				t.Errorf("%s: GetSpecifiedArticle of %s returned %q", test.role, articleID, res.GetTitle())
+			}
+
+			article, _ := store.get(ctx, articleID)
+			event := newWatchFilter(ctx, &web_log_pb.WatchArticlesRequest{}).event(articleEvent{after: &article})
+			if got := event != nil; got != test.sees[articleID] {
+				t.Errorf("%s: WatchArticles event of %s is %v", test.role, articleID, event)
+			}
+		}
+
+		list, err := s.ListArticles(ctx, &web_log_pb.ListArticlesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed := make(map[string]bool)
+		for _, summary := range list.GetArticles() {
+			listed[summary.GetArticleID()] = true
+		}
+		if len(listed) != len(test.sees) || int(list.GetTotalSize()) != len(test.sees) {
+			t.Errorf("%s: ListArticles returned %v, want %v", test.role, listed, test.sees)
+		}
+		for articleID := range test.sees {
+			if !listed[articleID] {
+				t.Errorf("%s: ListArticles is missing %s", test.role, articleID)
+			}
+		}
+	}
+
+	// the feeds are public, so they only list published articles
+	f := &feedServer{config: feedsConfig{Items: 10}}
+	rec := httptest.NewRecorder()
+	f.serveFeed(rec, httptest.NewRequest(http.MethodGet, "/feeds/rss.xml", nil))
+	body, _ := io.ReadAll(rec.Result().Body)
+	if !strings.Contains(string(body), "published title") {
+		t.Errorf("feed is missing the published article: %s", body)
+	}
+	for _, title := range []string{"draft title", "review title", "archived title"} {
+		if strings.Contains(string(body), title) {
+			t.Errorf("feed lists %q", title)
+		}
+	}
+}
+
+func TestWatchPublishingIsCreatedForReaders(t *testing.T) {
+	draft := Article{ArticleID: "a", Status: statusDraft}
+	published := Article{ArticleID: "a", Status: statusPublished}
+	event := articleEvent{before: &draft, after: &published}
+	if res := newWatchFilter(asRole(roleReader), &web_log_pb.WatchArticlesRequest{}).event(event); res.GetType() != web_log_pb.ArticleEventType_CREATED {
+		t.Errorf("reader got %v for a published draft, want CREATED", res)
+	}
+	if res := newWatchFilter(asRole(roleEditor), &web_log_pb.WatchArticlesRequest{}).event(event); res.GetType() != web_log_pb.ArticleEventType_UPDATED {
+		t.Errorf("editor got %v for a published draft, want UPDATED", res)
+	}
+}
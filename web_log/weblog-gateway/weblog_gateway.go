@@ -0,0 +1,24 @@
+// weblog-gateway serves the REST/JSON gateway as a sidecar of the web log server
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"grpc_web_log/webgateway"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "HTTP listen address")
+	endpoint := flag.String("endpoint", "127.0.0.1:50051", "gRPC address of the web log server")
+	flag.Parse()
+
+	handler, err := webgateway.New(context.Background(), *endpoint)
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+	fmt.Println("REST gateway on", *addr, "for", *endpoint)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
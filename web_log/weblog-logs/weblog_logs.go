@@ -0,0 +1,408 @@
+// weblog-logs searches logger/access.log and logger/error.log
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestamp format of text log lines
+const timeFormat = "2006-01-02T15:04:05.99-07:00"
+
+// time between two reads of a followed file
+const followInterval = 500 * time.Millisecond
+
+// severity of a log line, in the order of weblogger levels
+var levels = []string{"debug", "info", "warn", "error", "fatal"}
+
+// entry is one parsed log line
+type entry struct {
+	raw       string
+	time      time.Time
+	level     int
+	clientIP  string
+	method    string
+	code      string
+	articleID []string
+	access    bool
+}
+
+// filter is the command-line search
+type filter struct {
+	since     time.Time
+	until     time.Time
+	clientIP  string
+	method    string
+	minLevel  int
+	articleID string
+}
+
+var articleIDPattern = regexp.MustCompile(`articleID[=:]\s*"?([0-9a-fA-F-]{36})`)
+
+// key=value fields, a value may be quoted
+var fieldPattern = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*"|\S*)`)
+
+// Get the level of a name, -1 if unknown
+func levelOf(name string) int {
+	for i, level := range levels {
+		if strings.EqualFold(name, level) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Parse a text line or a JSON line written by weblogger
+func parseLine(line string) (entry, bool) {
+	e := entry{raw: line, level: levelOf("info")}
+	var message string
+	if strings.HasPrefix(line, "{") {
+		var record struct {
+			Time    string `json:"time"`
+			Level   string `json:"level"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return e, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, record.Time)
+		if err != nil {
+			return e, false
+		}
+		e.time = t
+		if level := levelOf(record.Level); level >= 0 {
+			e.level = level
+		}
+		message = record.Message
+	} else {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return e, false
+		}
+		t, err := time.Parse(timeFormat, line[:i])
+		if err != nil {
+			return e, false
+		}
+		e.time = t
+		message = line[i+1:]
+	}
+	parseMessage(&e, strings.TrimSpace(message))
+	for _, match := range articleIDPattern.FindAllStringSubmatch(message, -1) {
+		e.articleID = append(e.articleID, match[1])
+	}
+	return e, true
+}
+
+// Read the fields of an error line, an access record or an older access line
+func parseMessage(e *entry, message string) {
+	tokens := strings.Fields(message)
+	if len(tokens) == 0 {
+		return
+	}
+	// error.log: LEVEL clientIP method file line message
+	if level := levelOf(tokens[0]); level >= 0 && tokens[0] == strings.ToUpper(tokens[0]) {
+		e.level = level
+		for i := 1; i < len(tokens); i++ {
+			if strings.HasSuffix(tokens[i], ".go") && i+1 < len(tokens) {
+				if _, err := strconv.Atoi(tokens[i+1]); err == nil {
+					if i >= 2 {
+						e.method = tokens[i-1]
+					}
+					if i >= 3 {
+						e.clientIP = strings.Join(tokens[1:i-1], " ")
+					}
+					return
+				}
+			}
+		}
+		return
+	}
+
+	e.access = true
+	// access.log record: key=value fields
+	if strings.Contains(message, "method=") {
+		for _, match := range fieldPattern.FindAllStringSubmatch(message, -1) {
+			value := match[2]
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+			switch match[1] {
+			case "clientIP":
+				e.clientIP = value
+			case "method":
+				e.method = value
+			case "code":
+				e.code = value
+			}
+		}
+		return
+	}
+	// older access.log line: clientIP [principal] method payload
+	e.clientIP = tokens[0]
+	for _, token := range tokens[1:] {
+		if token != "" && token[0] >= 'A' && token[0] <= 'Z' {
+			e.method = token
+			return
+		}
+	}
+}
+
+// Strip the port of host:port
+func hostOf(addr string) string {
+	if i := strings.LastIndexByte(addr, ':'); i > 0 && !strings.HasSuffix(addr, "]") {
+		return strings.Trim(addr[:i], "[]")
+	}
+	return addr
+}
+
+func (f *filter) match(e entry) bool {
+	if !f.since.IsZero() && e.time.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && e.time.After(f.until) {
+		return false
+	}
+	if e.level < f.minLevel {
+		return false
+	}
+	if f.clientIP != "" && e.clientIP != f.clientIP && hostOf(e.clientIP) != f.clientIP {
+		return false
+	}
+	if f.method != "" && !strings.EqualFold(e.method, f.method) && !strings.EqualFold(path.Base(e.method), f.method) {
+		return false
+	}
+	if f.articleID != "" {
+		found := false
+		for _, id := range e.articleID {
+			if strings.EqualFold(id, f.articleID) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse an RFC 3339 time, or a duration before now such as 1h
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, timeFormat, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q", s)
+}
+
+// Read every line of the files
+func readFiles(files []string) ([]entry, error) {
+	var entries []entry
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			if e, ok := parseLine(scanner.Text()); ok {
+				entries = append(entries, e)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+	}
+	// merge the files by time
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.Before(entries[j].time)
+	})
+	return entries, nil
+}
+
+// methodStats are the counts of one RPC method
+type methodStats struct {
+	requests int
+	errors   int
+	logged   int
+}
+
+// Print per-method request counts, error rates and error.log lines
+func printStats(w io.Writer, entries []entry) {
+	stats := make(map[string]*methodStats)
+	get := func(method string) *methodStats {
+		method = path.Base(method)
+		if stats[method] == nil {
+			stats[method] = &methodStats{}
+		}
+		return stats[method]
+	}
+	for _, e := range entries {
+		if e.method == "" {
+			continue
+		}
+		switch {
+		case e.access:
+			s := get(e.method)
+			s.requests++
+			if e.code != "" && e.code != "OK" {
+				s.errors++
+			}
+		case e.level >= levelOf("error"):
+			get(e.method).logged++
+		}
+	}
+
+	methods := make([]string, 0, len(stats))
+	for method := range stats {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	fmt.Fprintf(w, "%-28s %10s %10s %10s %12s\n", "METHOD", "REQUESTS", "ERRORS", "ERROR%", "ERROR LINES")
+	for _, method := range methods {
+		s := stats[method]
+		rate := 0.0
+		if s.requests > 0 {
+			rate = 100 * float64(s.errors) / float64(s.requests)
+		}
+		fmt.Fprintf(w, "%-28s %10d %10d %9.1f%% %12d\n", method, s.requests, s.errors, rate, s.logged)
+	}
+}
+
+// Print the new lines of the files as they are written, like tail -f
+func follow(files []string, f *filter) {
+	lines := make(chan string)
+	for _, file := range files {
+		go followFile(file, lines)
+	}
+	for line := range lines {
+		if e, ok := parseLine(line); ok && f.match(e) {
+			fmt.Println(line)
+		}
+	}
+}
+
+// Send the lines appended to a file, starting at its current end, and reopen it after a rotation
+func followFile(file string, lines chan<- string) {
+	var offset int64
+	if info, err := os.Stat(file); err == nil {
+		offset = info.Size()
+	}
+	var partial string
+	for {
+		time.Sleep(followInterval)
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		// truncated or replaced by a new file
+		if info.Size() < offset {
+			offset = 0
+			partial = ""
+		}
+		if info.Size() == offset {
+			continue
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		f.Seek(offset, io.SeekStart)
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		offset += int64(len(data))
+		chunk := partial + string(data)
+		parts := strings.Split(chunk, "\n")
+		partial = parts[len(parts)-1]
+		for _, line := range parts[:len(parts)-1] {
+			lines <- line
+		}
+	}
+}
+
+func main() {
+	since := flag.String("since", "", "only lines at or after this time, RFC 3339 or a duration such as 1h")
+	until := flag.String("until", "", "only lines at or before this time, RFC 3339 or a duration such as 10m")
+	clientIP := flag.String("ip", "", "only lines of this client IP, with or without the port")
+	method := flag.String("method", "", "only lines of this RPC method, e.g. GetSpecifiedArticle")
+	level := flag.String("level", "debug", "only lines of this severity or above: debug, info, warn, error or fatal")
+	articleID := flag.String("article", "", "only lines mentioning this articleID")
+	stats := flag.Bool("stats", false, "print per-method request counts and error rates instead of lines")
+	followFlag := flag.Bool("f", false, "follow the files and print new lines as they are written")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: weblog-logs [flags] [log files], default logger/access.log logger/error.log")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	f := &filter{clientIP: *clientIP, method: *method, articleID: *articleID}
+	var err error
+	if f.since, err = parseTime(*since); err != nil {
+		fatal(err)
+	}
+	if f.until, err = parseTime(*until); err != nil {
+		fatal(err)
+	}
+	if f.minLevel = levelOf(*level); f.minLevel < 0 {
+		fatal(fmt.Errorf("unknown level %q", *level))
+	}
+	if *stats && *followFlag {
+		fatal(fmt.Errorf("-stats and -f cannot be used together"))
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"logger/access.log", "logger/error.log"}
+	}
+	if *followFlag {
+		follow(files, f)
+		return
+	}
+
+	entries, err := readFiles(files)
+	if err != nil {
+		fatal(err)
+	}
+	var matched []entry
+	for _, e := range entries {
+		if f.match(e) {
+			matched = append(matched, e)
+		}
+	}
+	if *stats {
+		printStats(os.Stdout, matched)
+		return
+	}
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	for _, e := range matched {
+		fmt.Fprintln(out, e.raw)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "weblog-logs:", err)
+	os.Exit(2)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"grpc_web_log/weblogger"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testArticleID = "123e4567-e89b-12d3-a456-426614174000"
+
+// Write an error line and an access record with weblogger, as text or JSON lines
+func writeLines(t *testing.T, asJSON bool) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	var w weblogger.Weblogger
+	if asJSON {
+		w.AddDefaultSink(weblogger.NewJSONWriterSink(&buf))
+	} else {
+		w.AddDefaultSink(weblogger.NewWriterSink(&buf))
+	}
+	w.With("10.0.0.1:5000", "jane", "trace").Error("GetSpecifiedArticle", "articleID is NOT existed.")
+	w.Access("start=2026-10-19T10:00:00Z", "duration=1ms", "clientIP=10.0.0.2:6000", "principal=jane",
+		"method=/web_log.WebLogService/RemoveSpecifiedArticle", "code=NotFound", `userAgent="grpc-go/1.0 test"`, "articleID="+testArticleID)
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func TestParseLine(t *testing.T) {
+	for _, asJSON := range []bool{false, true} {
+		lines := writeLines(t, asJSON)
+		if len(lines) != 2 {
+			t.Fatalf("json %v: weblogger wrote %q", asJSON, lines)
+		}
+
+		e, ok := parseLine(lines[0])
+		if !ok || e.access || e.level != levelOf("error") || e.clientIP != "10.0.0.1:5000" || e.method != "GetSpecifiedArticle" {
+			t.Errorf("json %v: error line %q parsed as %+v", asJSON, lines[0], e)
+		}
+		if time.Since(e.time) > time.Minute {
+			t.Errorf("json %v: error line time is %v", asJSON, e.time)
+		}
+
+		e, ok = parseLine(lines[1])
+		if !ok || !e.access || e.level != levelOf("info") || e.clientIP != "10.0.0.2:6000" ||
+			e.method != "/web_log.WebLogService/RemoveSpecifiedArticle" || e.code != "NotFound" ||
+			len(e.articleID) != 1 || e.articleID[0] != testArticleID {
+			t.Errorf("json %v: access line %q parsed as %+v", asJSON, lines[1], e)
+		}
+	}
+
+	// an access line written before the key=value records
+	e, ok := parseLine("2021-03-04T05:06:07.89+09:00 10.0.0.3:7000 GetAllArticles articleID: " + testArticleID)
+	if !ok || !e.access || e.clientIP != "10.0.0.3:7000" || e.method != "GetAllArticles" || len(e.articleID) != 1 {
+		t.Errorf("older access line parsed as %+v", e)
+	}
+	if want := time.Date(2021, 3, 4, 5, 6, 7, 890000000, time.FixedZone("", 9*60*60)); !e.time.Equal(want) {
+		t.Errorf("older access line time is %v, want %v", e.time, want)
+	}
+
+	for _, line := range []string{"", "not a log line", "{", `{"time":"yesterday","level":"info","message":"m"}`} {
+		if _, ok := parseLine(line); ok {
+			t.Errorf("%q was parsed", line)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	lines := []string{
+		"2026-10-19T10:00:00.00+00:00 start=x clientIP=10.0.0.1:5000 method=/web_log.WebLogService/GetAllArticles code=OK",
+		"2026-10-19T11:00:00.00+00:00  WARN 10.0.0.2:6000 GetSpecifiedArticle /src/web_log_server.go 12 slow",
+		`{"time":"2026-10-19T12:00:00Z","level":"error","message":"ERROR 10.0.0.1:5001 UpdateSpecifiedArticle /src/web_log_server.go 34 failed articleID=` + testArticleID + `"}`,
+	}
+	var entries []entry
+	for _, line := range lines {
+		e, ok := parseLine(line)
+		if !ok {
+			t.Fatalf("%q was not parsed", line)
+		}
+		entries = append(entries, e)
+	}
+	at := func(hour int) time.Time {
+		return time.Date(2026, 10, 19, hour, 30, 0, 0, time.UTC)
+	}
+
+	for _, test := range []struct {
+		name   string
+		filter filter
+		want   string
+	}{
+		{"no filter", filter{}, "012"},
+		{"since", filter{since: at(10)}, "12"},
+		{"until", filter{until: at(10)}, "0"},
+		{"client IP without port", filter{clientIP: "10.0.0.1"}, "02"},
+		{"client IP with port", filter{clientIP: "10.0.0.1:5001"}, "2"},
+		{"full method", filter{method: "/web_log.WebLogService/GetAllArticles"}, "0"},
+		{"short method in any case", filter{method: "getallarticles"}, "0"},
+		{"warn and above", filter{minLevel: levelOf("warn")}, "12"},
+		{"article", filter{articleID: strings.ToUpper(testArticleID)}, "2"},
+	} {
+		var got string
+		for i, e := range entries {
+			if test.filter.match(e) {
+				got += string(rune('0' + i))
+			}
+		}
+		if got != test.want {
+			t.Errorf("%s: matched lines %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	var entries []entry
+	for _, line := range []string{
+		"2026-10-19T10:00:00.00+00:00 clientIP=a method=/web_log.WebLogService/GetAllArticles code=OK",
+		"2026-10-19T10:00:01.00+00:00 clientIP=a method=/web_log.WebLogService/GetAllArticles code=OK",
+		"2026-10-19T10:00:02.00+00:00 clientIP=a method=/web_log.WebLogService/GetAllArticles code=Unavailable",
+		"2026-10-19T10:00:03.00+00:00 clientIP=a method=/web_log.WebLogService/GetAllArticles code=OK",
+		"2026-10-19T10:00:04.00+00:00  ERROR a GetAllArticles /src/web_log_server.go 12 read failed",
+		"2026-10-19T10:00:05.00+00:00  INFO a GetAllArticles /src/web_log_server.go 13 not counted",
+	} {
+		e, _ := parseLine(line)
+		entries = append(entries, e)
+	}
+	var out bytes.Buffer
+	printStats(&out, entries)
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(rows) != 2 || strings.Join(strings.Fields(rows[1]), " ") != "GetAllArticles 4 1 25.0% 1" {
+		t.Errorf("stats are\n%s", out.String())
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	if got, err := parseTime("2026-10-19T10:00:00Z"); err != nil || !got.Equal(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC 3339 time parsed as %v, %v", got, err)
+	}
+	if got, err := parseTime("1h"); err != nil || time.Since(got) < time.Hour || time.Since(got) > time.Hour+time.Minute {
+		t.Errorf("1h parsed as %v, %v", got, err)
+	}
+	if got, err := parseTime(""); err != nil || !got.IsZero() {
+		t.Errorf("empty time parsed as %v, %v", got, err)
+	}
+	if _, err := parseTime("tomorrow"); err == nil {
+		t.Error("tomorrow was parsed")
+	}
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"grpc_web_log/webfeed"
+	"grpc_web_log/webrender"
+	"html/template"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// the default theme, -theme replaces it with a directory of the same files
+//
+//go:embed theme
+var defaultTheme embed.FS
+
+// page templates of a theme, each is executed with layout.html
+var themePages = []string{"index.html", "article.html", "tag.html"}
+
+// the export state kept in the output directory for incremental rebuilds
+const manifestFile = ".weblog-export.json"
+
+// manifest records what the last export wrote
+type manifest struct {
+	// hash of the theme files and site settings, a change rebuilds every page
+	Theme string `json:"theme"`
+	// articleID -> version of the written article page
+	Articles map[string]int64 `json:"articles"`
+	// tag -> number of articles, every page lists the tags with their counts
+	Tags map[string]int `json:"tags"`
+}
+
+// siteData is the site part of the template data
+type siteData struct {
+	Title       string
+	Description string
+	// public URL of the site
+	Link string
+	// path of Link ending with /, page URLs start with it
+	Root string
+}
+
+// articleView is an article as seen by the templates
+type articleView struct {
+	ID       string
+	Title    string
+	URL      string
+	HTML     template.HTML
+	TOC      []webrender.Heading
+	Excerpt  string
+	Tags     []tagView
+	Category string
+	Version  int64
+	Created  time.Time
+	Updated  time.Time
+}
+
+// tagView is a tag with the number of its articles
+type tagView struct {
+	Name  string
+	URL   string
+	Count int
+}
+
+// pageData is passed to every template
+type pageData struct {
+	Site  siteData
+	Title string
+	// article.html
+	Article *articleView
+	// tag.html
+	Tag string
+	// index.html and tag.html, newest first
+	Articles []*articleView
+	// every tag, by name
+	Tags []tagView
+}
+
+// exporter writes the static site
+type exporter struct {
+	out       string
+	site      siteData
+	items     int
+	templates map[string]*template.Template
+	theme     fs.FS
+	articles  []*articleView
+	byID      map[string]*articleView
+	tags      []tagView
+	written   int
+}
+
+func exportSite(args []string) {
+	flags := flag.NewFlagSet("export-site", flag.ExitOnError)
+	storePath := flags.String("store", "conf/saveArticles.json", "the article store")
+	walPath := flags.String("wal", "conf/saveArticles.wal", "the write-ahead log of the store")
+	out := flags.String("out", "site", "output directory")
+	themeDir := flags.String("theme", "", "directory with layout.html, index.html, article.html, tag.html and static files, the built-in theme when empty")
+	title := flags.String("title", "gRPC web log", "site title")
+	description := flags.String("description", "", "site description")
+	link := flags.String("link", "http://localhost:8000/", "public URL of the site, used by sitemap.xml and the feed")
+	items := flags.Int("items", 20, "newest articles in the feed")
+	force := flags.Bool("force", false, "rewrite every page, not only the changed articles")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: weblog export-site [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	base, err := url.Parse(*link)
+	if err != nil || !base.IsAbs() {
+		fatal(fmt.Errorf("-link %q is not an absolute URL", *link))
+	}
+	root := base.Path
+	if !strings.HasSuffix(root, "/") {
+		root += "/"
+	}
+	var theme fs.FS
+	if *themeDir == "" {
+		theme, _ = fs.Sub(defaultTheme, "theme")
+	} else {
+		theme = os.DirFS(*themeDir)
+	}
+
+	articles, err := readStore(*storePath, *walPath)
+	if err != nil {
+		fatal(err)
+	}
+	e := &exporter{
+		out:   *out,
+		site:  siteData{Title: *title, Description: *description, Link: *link, Root: root},
+		items: *items,
+		theme: theme,
+	}
+	if err := e.loadTheme(); err != nil {
+		fatal(err)
+	}
+	if err := e.export(published(articles), *force); err != nil {
+		fatal(err)
+	}
+}
+
+// Parse each page template with layout.html
+func (e *exporter) loadTheme() error {
+	funcs := template.FuncMap{
+		"date": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format("2006-01-02")
+		},
+	}
+	e.templates = make(map[string]*template.Template)
+	for _, page := range themePages {
+		t, err := template.New("layout.html").Funcs(funcs).ParseFS(e.theme, "layout.html", page)
+		if err != nil {
+			return fmt.Errorf("theme: %v", err)
+		}
+		e.templates[page] = t
+	}
+	return nil
+}
+
+// Hash the theme files and the site settings
+func (e *exporter) themeHash() (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(e.theme, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(e.theme, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s %d\n", name, len(data))
+		h.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	settings, _ := json.Marshal(e.site)
+	h.Write(settings)
+	fmt.Fprintf(h, "items %d\n", e.items)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Read the manifest of the last export, empty when there is none
+func (e *exporter) readManifest() manifest {
+	m := manifest{Articles: make(map[string]int64)}
+	data, err := os.ReadFile(filepath.Join(e.out, manifestFile))
+	if err == nil {
+		json.Unmarshal(data, &m)
+	}
+	if m.Articles == nil {
+		m.Articles = make(map[string]int64)
+	}
+	return m
+}
+
+// Write the pages of new and changed articles, remove the pages of removed articles,
+// and rewrite the index, tag pages, sitemap and feed when anything changed
+func (e *exporter) export(articles []Article, force bool) error {
+	hash, err := e.themeHash()
+	if err != nil {
+		return err
+	}
+	last := e.readManifest()
+	rebuild := force || last.Theme != hash
+
+	articles = exportable(articles)
+	next := manifest{Theme: hash, Articles: make(map[string]int64, len(articles)), Tags: tagCounts(articles)}
+	// a changed tag set or count is shown by every page
+	rebuild = rebuild || !maps.Equal(last.Tags, next.Tags)
+
+	e.views(articles, next.Tags)
+	changed := rebuild || len(last.Articles) != len(articles)
+	for _, article := range articles {
+		next.Articles[article.ArticleID] = article.Version
+		version, ok := last.Articles[article.ArticleID]
+		if !rebuild && ok && version == article.Version {
+			continue
+		}
+		changed = true
+		if err := e.writePage("article.html", path.Join("articles", article.ArticleID, "index.html"), pageData{
+			Site:    e.site,
+			Title:   article.Title,
+			Article: e.byID[article.ArticleID],
+			Tags:    e.tags,
+		}); err != nil {
+			return err
+		}
+	}
+	for articleID := range last.Articles {
+		if _, ok := next.Articles[articleID]; !ok && validArticleID(articleID) {
+			changed = true
+			if err := os.RemoveAll(filepath.Join(e.out, "articles", articleID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if changed {
+		if err := e.writeIndexes(); err != nil {
+			return err
+		}
+		if err := e.copyStatic(); err != nil {
+			return err
+		}
+	}
+	data, _ := json.MarshalIndent(next, "", "  ")
+	if err := os.WriteFile(filepath.Join(e.out, manifestFile), data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("%d articles, %d files written to %s\n", len(articles), e.written, e.out)
+	return nil
+}
+
+// Check that an article ID names a single directory under articles/
+func validArticleID(articleID string) bool {
+	return articleID != "" && articleID != "." && !strings.ContainsAny(articleID, `/\`) && !strings.Contains(articleID, "..")
+}
+
+// Drop the articles whose ID cannot be a page directory
+func exportable(articles []Article) []Article {
+	var kept []Article
+	for _, article := range articles {
+		if !validArticleID(article.ArticleID) {
+			fmt.Fprintf(os.Stderr, "weblog: article %q: ID is not a valid directory name, skipped\n", article.ArticleID)
+			continue
+		}
+		kept = append(kept, article)
+	}
+	return kept
+}
+
+// Count the articles of each tag
+func tagCounts(articles []Article) map[string]int {
+	counts := make(map[string]int)
+	for _, article := range articles {
+		for _, tag := range article.Tags {
+			counts[tag]++
+		}
+	}
+	return counts
+}
+
+// Render the articles for the templates, newest first, with the tag counts
+func (e *exporter) views(articles []Article, counts map[string]int) {
+	for name, count := range counts {
+		e.tags = append(e.tags, tagView{Name: name, URL: e.tagURL(name), Count: count})
+	}
+	sort.Slice(e.tags, func(i, j int) bool { return e.tags[i].Name < e.tags[j].Name })
+
+	e.byID = make(map[string]*articleView, len(articles))
+	for _, article := range articles {
+		view := &articleView{
+			ID:       article.ArticleID,
+			Title:    article.Title,
+			Category: article.Category,
+			URL:      e.site.Root + "articles/" + article.ArticleID + "/",
+			Version:  article.Version,
+			Created:  article.Created,
+			Updated:  article.Updated,
+		}
+		rendered, err := webrender.Render(article.Content, article.ContentFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "weblog: article %s: %v\n", article.ArticleID, err)
+			rendered = &webrender.Rendered{}
+		}
+		// sanitized by webrender
+		view.HTML = template.HTML(rendered.HTML)
+		view.TOC = rendered.TOC
+		view.Excerpt = rendered.Excerpt
+		for _, tag := range article.Tags {
+			view.Tags = append(view.Tags, tagView{Name: tag, URL: e.tagURL(tag), Count: counts[tag]})
+		}
+		e.articles = append(e.articles, view)
+		e.byID[article.ArticleID] = view
+	}
+	sort.SliceStable(e.articles, func(i, j int) bool {
+		return e.articles[i].Created.After(e.articles[j].Created)
+	})
+}
+
+// Get the file name of a tag page directory
+func tagSlug(tag string) string {
+	if slug := webrender.Slug(tag); slug != "" {
+		return slug
+	}
+	sum := sha256.Sum256([]byte(tag))
+	return hex.EncodeToString(sum[:4])
+}
+
+func (e *exporter) tagURL(tag string) string {
+	return e.site.Root + "tags/" + tagSlug(tag) + "/"
+}
+
+// Execute a page template into a file of the output directory
+func (e *exporter) writePage(page string, name string, data pageData) error {
+	var buf bytes.Buffer
+	if err := e.templates[page].Execute(&buf, data); err != nil {
+		return fmt.Errorf("%s: %v", page, err)
+	}
+	return e.writeFile(name, buf.Bytes())
+}
+
+func (e *exporter) writeFile(name string, data []byte) error {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return fmt.Errorf("%s is outside the output directory", name)
+	}
+	file := filepath.Join(e.out, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	e.written++
+	return os.WriteFile(file, data, 0644)
+}
+
+// Write the index, the tag pages, sitemap.xml and atom.xml
+func (e *exporter) writeIndexes() error {
+	if err := e.writePage("index.html", "index.html", pageData{Site: e.site, Title: e.site.Title, Articles: e.articles, Tags: e.tags}); err != nil {
+		return err
+	}
+
+	// tags of removed articles disappear
+	if err := os.RemoveAll(filepath.Join(e.out, "tags")); err != nil {
+		return err
+	}
+	for _, tag := range e.tags {
+		var tagged []*articleView
+		for _, view := range e.articles {
+			for _, t := range view.Tags {
+				if t.Name == tag.Name {
+					tagged = append(tagged, view)
+					break
+				}
+			}
+		}
+		if err := e.writePage("tag.html", path.Join("tags", tagSlug(tag.Name), "index.html"), pageData{
+			Site:     e.site,
+			Title:    tag.Name,
+			Tag:      tag.Name,
+			Articles: tagged,
+			Tags:     e.tags,
+		}); err != nil {
+			return err
+		}
+	}
+
+	sitemap, err := e.sitemap()
+	if err != nil {
+		return err
+	}
+	if err := e.writeFile("sitemap.xml", sitemap); err != nil {
+		return err
+	}
+	feed, err := e.feed().Atom()
+	if err != nil {
+		return err
+	}
+	return e.writeFile("atom.xml", feed)
+}
+
+// sitemapURL is a <url> of sitemap.xml
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// Get the absolute URL of a page URL
+func (e *exporter) absolute(pageURL string) string {
+	base, _ := url.Parse(e.site.Link)
+	return base.ResolveReference(&url.URL{Path: pageURL}).String()
+}
+
+func lastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Write the sitemap of the index, article and tag pages
+func (e *exporter) sitemap() ([]byte, error) {
+	set := sitemapURLSet{URLs: []sitemapURL{{Loc: e.absolute(e.site.Root)}}}
+	for _, view := range e.articles {
+		set.URLs = append(set.URLs, sitemapURL{Loc: e.absolute(view.URL), LastMod: lastMod(view.Updated)})
+	}
+	for _, tag := range e.tags {
+		set.URLs = append(set.URLs, sitemapURL{Loc: e.absolute(tag.URL)})
+	}
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// Build the Atom feed of the newest articles
+func (e *exporter) feed() *webfeed.Feed {
+	feed := &webfeed.Feed{
+		Title:       e.site.Title,
+		Description: e.site.Description,
+		Link:        e.absolute(e.site.Root),
+		Self:        e.absolute(e.site.Root + "atom.xml"),
+		Author:      e.site.Title,
+	}
+	newest := append([]*articleView(nil), e.articles...)
+	sort.SliceStable(newest, func(i, j int) bool { return newest[i].Created.After(newest[j].Created) })
+	if len(newest) > e.items {
+		newest = newest[:e.items]
+	}
+	for _, view := range newest {
+		if view.Updated.After(feed.Updated) {
+			feed.Updated = view.Updated
+		}
+		item := webfeed.Item{
+			ID:        "urn:uuid:" + view.ID,
+			Title:     view.Title,
+			Link:      e.absolute(view.URL),
+			Summary:   view.Excerpt,
+			Published: view.Created,
+			Updated:   view.Updated,
+		}
+		for _, tag := range view.Tags {
+			item.Tags = append(item.Tags, tag.Name)
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now()
+	}
+	return feed
+}
+
+// Copy the files of the theme that are not templates, such as style.css
+func (e *exporter) copyStatic() error {
+	return fs.WalkDir(e.theme, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasSuffix(name, ".html") {
+			return err
+		}
+		data, err := fs.ReadFile(e.theme, name)
+		if err != nil {
+			return err
+		}
+		return e.writeFile(name, data)
+	})
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Export the articles with the built-in theme
+func testExport(t *testing.T, out string, articles []Article) {
+	t.Helper()
+	theme, _ := fs.Sub(defaultTheme, "theme")
+	e := &exporter{out: out, site: siteData{Title: "test", Link: "http://localhost/", Root: "/"}, items: 10, theme: theme}
+	if err := e.loadTheme(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.export(articles, false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportRebuildsOnTagChange(t *testing.T) {
+	out := t.TempDir()
+	a := Article{ArticleID: "a", Title: "A", Content: "a", Version: 1, Tags: []string{"go"}}
+	b := Article{ArticleID: "b", Title: "B", Content: "b", Version: 1}
+	testExport(t, out, []Article{a, b})
+
+	// only b changes, the page of a shows the new count of its tag
+	b.Version, b.Tags = 2, []string{"go"}
+	testExport(t, out, []Article{a, b})
+	data, err := os.ReadFile(filepath.Join(out, "articles", "a", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "go (2)") {
+		t.Errorf("page of a was not rebuilt:\n%s", data)
+	}
+}
+
+func TestExportSkipsUnsafeIDs(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "site")
+	testExport(t, out, []Article{
+		{ArticleID: "../outside", Title: "E", Content: "e", Version: 1},
+		{ArticleID: "..", Title: "E", Content: "e", Version: 1},
+		{ArticleID: "ok", Title: "OK", Content: "ok", Version: 1},
+	})
+	if _, err := os.Stat(filepath.Join(dir, "outside")); err == nil {
+		t.Error("an article page was written outside the output directory")
+	}
+	if _, err := os.Stat(filepath.Join(out, "articles", "ok", "index.html")); err != nil {
+		t.Error(err)
+	}
+
+	// a manifest entry with an unsafe ID does not remove anything outside
+	keep := filepath.Join(dir, "keep")
+	os.Mkdir(keep, 0755)
+	os.WriteFile(filepath.Join(out, manifestFile), []byte(`{"articles":{"../../keep":1}}`), 0644)
+	testExport(t, out, nil)
+	if _, err := os.Stat(keep); err != nil {
+		t.Error("removing a stale page removed a directory outside the output directory")
+	}
+}
@@ -0,0 +1,80 @@
+// weblog is the command-line tool of the web log: weblog <command> [flags]
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"grpc_web_log/webarticle"
+	"os"
+)
+
+// Article is an article of saveArticles.json, the server's
+type Article = webarticle.Article
+
+// Read the articles of the store: saveArticles.json with the write-ahead log not yet compacted into it replayed on top
+func readStore(storePath string, walPath string) ([]Article, error) {
+	data, err := os.ReadFile(storePath)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, &articles); err != nil {
+			return nil, fmt.Errorf("%s: %v", storePath, err)
+		}
+	}
+
+	file, err := os.Open(walPath)
+	if os.IsNotExist(err) {
+		return articles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	index := webarticle.Index(articles)
+	for _, record := range webarticle.ReadRecords(file) {
+		articles = webarticle.Apply(articles, index, record)
+	}
+	return articles, nil
+}
+
+// Keep the published articles, drafts and articles in review or archived are not exported
+func published(articles []Article) []Article {
+	var kept []Article
+	for _, article := range articles {
+		if article.Status == "" || article.Status == "published" {
+			kept = append(kept, article)
+		}
+	}
+	return kept
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: weblog <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  export-site  write the articles as a static HTML site")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "export-site":
+		exportSite(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "weblog: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "weblog:", err)
+	os.Exit(1)
+}
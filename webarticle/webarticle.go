@@ -0,0 +1,92 @@
+package webarticle
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Article is an article of saveArticles.json and of the write-ahead log
+type Article struct {
+	ArticleID string `json:"articleID"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	// plain, markdown or html
+	ContentFormat string `json:"contentFormat,omitempty"`
+	// 1 when saved, incremented by every update
+	Version int64    `json:"version,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	// a single category, empty when none
+	Category string `json:"category,omitempty"`
+	// when the article was saved and last updated
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	// draft, in_review, published or archived, empty is published
+	Status string `json:"status,omitempty"`
+	// when a draft or in_review article is published by publishLoop, nil when not scheduled
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
+
+// write-ahead log operations
+const (
+	OpSave   = "save"
+	OpUpdate = "update"
+	OpRemove = "remove"
+)
+
+// Record is one line of the write-ahead log
+type Record struct {
+	Op      string  `json:"op"`
+	Article Article `json:"article"`
+}
+
+// Read every complete record of a write-ahead log, a torn last line from a crash during append is skipped
+func ReadRecords(r io.Reader) []Record {
+	var records []Record
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// a line without '\n' was never fsynced completely
+			return records
+		}
+		var record Record
+		if json.Unmarshal(line, &record) != nil {
+			return records
+		}
+		records = append(records, record)
+	}
+}
+
+// Index the articles by ID
+func Index(articles []Article) map[string]int {
+	index := make(map[string]int, len(articles))
+	for i, article := range articles {
+		index[article.ArticleID] = i
+	}
+	return index
+}
+
+// Apply a record to the articles and their index, replaying a record twice gives the same result
+func Apply(articles []Article, index map[string]int, record Record) []Article {
+	i, exist := index[record.Article.ArticleID]
+	switch record.Op {
+	case OpSave, OpUpdate:
+		if exist {
+			articles[i] = record.Article
+		} else {
+			index[record.Article.ArticleID] = len(articles)
+			articles = append(articles, record.Article)
+		}
+	case OpRemove:
+		if exist {
+			articles = append(articles[:i], articles[i+1:]...)
+			delete(index, record.Article.ArticleID)
+			for j := i; j < len(articles); j++ {
+				index[articles[j].ArticleID] = j
+			}
+		}
+	}
+	return articles
+}
@@ -0,0 +1,163 @@
+package webfeed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Feed is a list of articles published as RSS 2.0 or Atom
+type Feed struct {
+	Title       string
+	Description string
+	// public URL of the web log
+	Link string
+	// URL of the Atom document itself, also its id
+	Self   string
+	Author string
+	// time of the newest change
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is one article of a feed
+type Item struct {
+	// a URN such as urn:uuid:<articleID>
+	ID      string
+	Title   string
+	Link    string
+	Summary string
+	// zero when unknown
+	Published time.Time
+	Updated   time.Time
+	Tags      []string
+}
+
+// rssFeed is an RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// atomFeed is an Atom (RFC 4287) document
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Link       atomLink       `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// RSS returns the feed as an RSS 2.0 document
+func (f *Feed) RSS() ([]byte, error) {
+	channel := rssChannel{
+		Title:         f.Title,
+		Link:          f.Link,
+		Description:   f.Description,
+		LastBuildDate: f.Updated.Format(time.RFC1123Z),
+		Generator:     "grpc_web_log",
+	}
+	for _, item := range f.Items {
+		rss := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			GUID:        rssGUID{Value: item.ID},
+			Categories:  item.Tags,
+		}
+		if !item.Published.IsZero() {
+			rss.PubDate = item.Published.Format(time.RFC1123Z)
+		}
+		channel.Items = append(channel.Items, rss)
+	}
+	return marshal(rssFeed{Version: "2.0", Channel: channel})
+}
+
+// Atom returns the feed as an Atom document, items without an update time are dated like the feed
+func (f *Feed) Atom() ([]byte, error) {
+	feed := atomFeed{
+		Title:   f.Title,
+		ID:      f.Self,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links:   []atomLink{{Href: f.Link}, {Href: f.Self, Rel: "self"}},
+		Author:  atomPerson{Name: f.Author},
+	}
+	for _, item := range f.Items {
+		updated := item.Updated
+		if updated.IsZero() {
+			updated = f.Updated
+		}
+		entry := atomEntry{
+			Title:   item.Title,
+			ID:      item.ID,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Link:    atomLink{Href: item.Link},
+			Summary: item.Summary,
+		}
+		if !item.Published.IsZero() {
+			entry.Published = item.Published.UTC().Format(time.RFC3339)
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return marshal(feed)
+}
+
+// Write an XML document with its header
+func marshal(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,36 @@
+package webgateway
+
+import (
+	"context"
+	"grpc_web_log/web_log/web_log_pb"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// New returns a handler serving the REST routes of web_log.proto by calling the gRPC server at endpoint,
+// and the OpenAPI spec at /openapi.json. gRPC status codes are mapped to HTTP status codes,
+// e.g. Unauthenticated -> 401, PermissionDenied -> 403, ResourceExhausted -> 429.
+// The Authorization header is forwarded as gRPC metadata.
+func New(ctx context.Context, endpoint string, opts ...grpc.DialOption) (http.Handler, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	gwmux := runtime.NewServeMux()
+	if err := web_log_pb.RegisterWebLogServiceHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return nil, err
+	}
+	if err := web_log_pb.RegisterCommentServiceHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwmux)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(web_log_pb.OpenAPISpec)
+	})
+	return mux, nil
+}
@@ -0,0 +1,313 @@
+package weblogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// timestamp format of file and stdout lines
+const timeFormat = "2006-01-02T15:04:05.99-07:00"
+
+// Record is one log line
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+}
+
+// Sink is a destination of log records
+type Sink interface {
+	Write(r Record) error
+	Close() error
+}
+
+// WriterSink writes records as text or JSON lines to an io.Writer, e.g. os.Stdout for containers
+type WriterSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	json   bool
+}
+
+// NewWriterSink returns a sink writing text lines to w, which is not closed by Close
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{writer: w}
+}
+
+// NewJSONWriterSink returns a sink writing JSON lines to w, which is not closed by Close
+func NewJSONWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{writer: w, json: true}
+}
+
+// NewFileSink returns a sink appending text lines to the log file, creating it if needed
+func NewFileSink(filePath string) (*WriterSink, error) {
+	logfile, err := isLogFileExist(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &WriterSink{writer: logfile, closer: logfile}, nil
+}
+
+// NewJSONFileSink returns a sink appending JSON lines to the log file, creating it if needed
+func NewJSONFileSink(filePath string) (*WriterSink, error) {
+	sink, err := NewFileSink(filePath)
+	if err != nil {
+		return nil, err
+	}
+	sink.json = true
+	return sink, nil
+}
+
+// jsonRecord is a record as written by a JSON sink
+type jsonRecord struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Write a "timestamp message" line, or a {"time","level","message"} object
+func (s *WriterSink) Write(r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writer == nil {
+		return nil
+	}
+	line := r.Time.Format(timeFormat) + " " + r.Message
+	if s.json {
+		out, err := json.Marshal(jsonRecord{
+			Time:    r.Time.Format(time.RFC3339Nano),
+			Level:   r.Level.String(),
+			Message: strings.TrimSpace(r.Message),
+		})
+		if err != nil {
+			return err
+		}
+		line = string(out)
+	}
+	_, err := io.WriteString(s.writer, line+"\n")
+	return err
+}
+
+// Close flushes and closes a file, later records are discarded
+func (s *WriterSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer = nil
+	if s.closer == nil {
+		return nil
+	}
+	if f, ok := s.closer.(*os.File); ok {
+		f.Sync()
+	}
+	err := s.closer.Close()
+	s.closer = nil
+	return err
+}
+
+// syslog facility user-level messages
+const facilityUser = 1
+
+// RFC 5424 timestamp, RFC 3339 with at most 6 digits of the second fraction
+const syslogTime = "2006-01-02T15:04:05.999999Z07:00"
+
+// RFC 5424 severity of each level
+var syslogSeverity = []int{
+	LevelDebug: 7,
+	LevelInfo:  6,
+	LevelWarn:  4,
+	LevelError: 3,
+	LevelFatal: 2,
+}
+
+// SyslogSink sends records in RFC 5424 format to a local syslog or journald socket
+// (network "unixgram" or "unix", e.g. /dev/log) or to a remote collector ("tcp" or "udp").
+// TCP messages are framed by octet counting (RFC 6587).
+type SyslogSink struct {
+	mu       sync.Mutex
+	network  string
+	addr     string
+	appName  string
+	hostname string
+	conn     net.Conn
+	// Timeout bounds the dial and each write, so a stalled collector does not stall the logging goroutine
+	Timeout time.Duration
+}
+
+// default dial and write timeout of a SyslogSink
+const defaultSyslogTimeout = 2 * time.Second
+
+// NewSyslogSink returns a sink that connects on the first write and reconnects after an error
+func NewSyslogSink(network string, addr string, appName string) (*SyslogSink, error) {
+	switch network {
+	case "unixgram", "unix", "tcp", "udp":
+	default:
+		return nil, fmt.Errorf("unknown syslog network %q", network)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	if appName == "" {
+		appName = "-"
+	}
+	return &SyslogSink{network: network, addr: addr, appName: appName, hostname: hostname, Timeout: defaultSyslogTimeout}, nil
+}
+
+// Format a record as an RFC 5424 message
+func (s *SyslogSink) format(r Record) string {
+	severity := syslogSeverity[LevelInfo]
+	if r.Level >= LevelDebug && r.Level <= LevelFatal {
+		severity = syslogSeverity[r.Level]
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		facilityUser*8+severity,
+		r.Time.Format(syslogTime),
+		s.hostname,
+		s.appName,
+		os.Getpid(),
+		strings.TrimSpace(r.Message))
+}
+
+// Write sends one message, the connection is dropped on error and redialed by the next write
+func (s *SyslogSink) Write(r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.addr, s.Timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	msg := s.format(r)
+	if s.network == "tcp" || s.network == "unix" {
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(s.Timeout))
+	if _, err := io.WriteString(s.conn, msg); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close the connection
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// Backpressure is what a BufferedSink does when its buffer is full
+type Backpressure int
+
+const (
+	// Drop discards the record
+	Drop Backpressure = iota
+	// Block waits for room in the buffer
+	Block
+)
+
+// ParseBackpressure converts drop or block to a Backpressure, drop when empty
+func ParseBackpressure(s string) (Backpressure, error) {
+	switch strings.ToLower(s) {
+	case "", "drop":
+		return Drop, nil
+	case "block":
+		return Block, nil
+	}
+	return Drop, fmt.Errorf("unknown backpressure %q", s)
+}
+
+// ErrDropped is returned when a full BufferedSink drops a record
+var ErrDropped = errors.New("log buffer full, record dropped")
+
+// BufferedSink writes records to another sink from a background goroutine
+type BufferedSink struct {
+	sink         Sink
+	records      chan Record
+	backpressure Backpressure
+	done         chan struct{}
+	mu           sync.RWMutex
+	closed       bool
+	dropped      uint64
+	// OnError is called when the wrapped sink fails to write a record
+	OnError func(err error)
+}
+
+// NewBufferedSink buffers up to size records in front of sink
+func NewBufferedSink(sink Sink, size int, backpressure Backpressure) *BufferedSink {
+	b := &BufferedSink{
+		sink:         sink,
+		records:      make(chan Record, size),
+		backpressure: backpressure,
+		done:         make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+func (b *BufferedSink) run() {
+	defer close(b.done)
+	for r := range b.records {
+		if err := b.sink.Write(r); err != nil && b.OnError != nil {
+			b.OnError(err)
+		}
+	}
+}
+
+// Write queues the record, blocking or dropping it when the buffer is full
+func (b *BufferedSink) Write(r Record) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return nil
+	}
+	if b.backpressure == Block {
+		b.records <- r
+		return nil
+	}
+	select {
+	case b.records <- r:
+		return nil
+	default:
+		atomic.AddUint64(&b.dropped, 1)
+		return ErrDropped
+	}
+}
+
+// Dropped is the number of records dropped because the buffer was full
+func (b *BufferedSink) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+// Close writes the buffered records, then closes the wrapped sink
+func (b *BufferedSink) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.records)
+	b.mu.Unlock()
+	<-b.done
+	return b.sink.Close()
+}
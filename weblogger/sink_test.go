@@ -0,0 +1,428 @@
+package weblogger
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memSink keeps the messages written to it
+type memSink struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *memSink) Write(r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, strings.TrimSpace(r.Message))
+	return nil
+}
+
+func (s *memSink) Close() error { return nil }
+
+func (s *memSink) lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// blockingSink waits for release before each write
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) Write(r Record) error {
+	<-s.release
+	return nil
+}
+
+func (s *blockingSink) Close() error { return nil }
+
+// Read one octet-counted message (RFC 6587) of a syslog stream
+func readFrame(r *bufio.Reader) (string, error) {
+	length, err := r.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		return "", err
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return "", err
+	}
+	return string(msg), nil
+}
+
+// fakeCollector accepts stream connections and sends the messages it reads to a channel, stop closes the listener and the connections
+func fakeCollector(t *testing.T, network string, addr string) (ln net.Listener, messages <-chan string, stop func()) {
+	t.Helper()
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan string, 16)
+	var mu sync.Mutex
+	var conns []net.Conn
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			go func() {
+				r := bufio.NewReader(conn)
+				for {
+					msg, err := readFrame(r)
+					if err != nil {
+						return
+					}
+					received <- msg
+				}
+			}()
+		}
+	}()
+	stop = func() {
+		ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	t.Cleanup(stop)
+	return ln, received, stop
+}
+
+func receive(t *testing.T, messages <-chan string) string {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector received nothing")
+	}
+	return ""
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	ln, messages, _ := fakeCollector(t, "tcp", "127.0.0.1:0")
+	sink, err := NewSyslogSink("tcp", ln.Addr().String(), "weblog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(Record{Time: time.Now(), Level: LevelError, Message: "disk full\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(Record{Time: time.Now(), Level: LevelDebug, Message: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	// user facility 1*8, error severity 3, debug severity 7
+	if msg := receive(t, messages); !strings.HasPrefix(msg, "<11>1 ") || !strings.Contains(msg, " weblog ") || !strings.HasSuffix(msg, " - - disk full") {
+		t.Errorf("collector received %q", msg)
+	}
+	if msg := receive(t, messages); !strings.HasPrefix(msg, "<15>1 ") || !strings.HasSuffix(msg, " second") {
+		t.Errorf("collector received %q", msg)
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	sink, err := NewSyslogSink("udp", conn.LocalAddr().String(), "weblog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(Record{Time: time.Now(), Level: LevelWarn, Message: "slow request"}); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a datagram is one message, without octet counting
+	if msg := string(buf[:n]); !strings.HasPrefix(msg, "<12>1 ") || !strings.HasSuffix(msg, " slow request") {
+		t.Errorf("collector received %q", msg)
+	}
+}
+
+func TestSyslogSinkReconnects(t *testing.T) {
+	ln, messages, stop := fakeCollector(t, "tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	sink, err := NewSyslogSink("tcp", addr, "weblog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	if err := sink.Write(Record{Time: time.Now(), Level: LevelInfo, Message: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, messages)
+
+	// the collector restarts, writes to the old connection fail and the sink dials again
+	stop()
+	_, messages, _ = fakeCollector(t, "tcp", addr)
+	for i := 0; i < 50; i++ {
+		sink.Write(Record{Time: time.Now(), Level: LevelInfo, Message: "again"})
+		select {
+		case msg := <-messages:
+			if !strings.HasSuffix(msg, " again") {
+				t.Errorf("collector received %q", msg)
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	t.Fatal("sink did not reconnect to the restarted collector")
+}
+
+func TestSyslogSinkStalledCollector(t *testing.T) {
+	dir, err := os.MkdirTemp("", "syslog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// the collector accepts the connection and never reads
+	ln, err := net.Listen("unix", filepath.Join(dir, "log.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(30 * time.Second)
+		}
+	}()
+
+	sink, err := NewSyslogSink("unix", ln.Addr().String(), "weblog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Timeout = 100 * time.Millisecond
+
+	big := strings.Repeat("x", 64*1024)
+	start := time.Now()
+	for i := 0; i < 1000; i++ {
+		err = sink.Write(Record{Time: time.Now(), Level: LevelInfo, Message: big})
+		if err != nil {
+			break
+		}
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("write to a stalled collector returned %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("write to a stalled collector took %v", elapsed)
+	}
+}
+
+func TestParseBackpressure(t *testing.T) {
+	for s, want := range map[string]Backpressure{"": Drop, "drop": Drop, "Block": Block} {
+		if got, err := ParseBackpressure(s); err != nil || got != want {
+			t.Errorf("ParseBackpressure(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseBackpressure("wait"); err == nil {
+		t.Error("ParseBackpressure accepted wait")
+	}
+}
+
+func TestBufferedSinkDrops(t *testing.T) {
+	stalled := &blockingSink{release: make(chan struct{})}
+	b := NewBufferedSink(stalled, 1, Drop)
+
+	// the first record is taken by the goroutine, the second fills the buffer
+	b.Write(Record{Message: "1"})
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = b.Write(Record{Message: "more"})
+	}
+	if err != ErrDropped || b.Dropped() == 0 {
+		t.Fatalf("full buffer returned %v with %d dropped, want ErrDropped", err, b.Dropped())
+	}
+	close(stalled.release)
+	b.Close()
+}
+
+func TestRouteFansOut(t *testing.T) {
+	var w Weblogger
+	file, routed, stdout := &memSink{}, &memSink{}, &memSink{}
+	w.AddDefaultSink(file)
+	w.AddSink(stdout)
+	w.Route(routed, LevelDebug)
+	w.SetLevel(LevelDebug)
+
+	w.Debug("Method", "debug line")
+	w.Error("Method", "error line")
+
+	has := func(sink *memSink, s string) bool {
+		for _, line := range sink.lines() {
+			if strings.Contains(line, s) {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(routed, "debug line") || has(routed, "error line") {
+		t.Errorf("routed sink got %q", routed.lines())
+	}
+	if has(file, "debug line") || !has(file, "error line") {
+		t.Errorf("default sink got %q", file.lines())
+	}
+	if !has(stdout, "debug line") || !has(stdout, "error line") {
+		t.Errorf("added sink got %q", stdout.lines())
+	}
+}
+
+// gateSink reports each write on entered, then waits for release
+type gateSink struct {
+	memSink
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newGateSink() *gateSink {
+	return &gateSink{entered: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (s *gateSink) Write(r Record) error {
+	s.entered <- struct{}{}
+	<-s.release
+	return s.memSink.Write(r)
+}
+
+func TestBufferedSinkCountsDrops(t *testing.T) {
+	stalled := newGateSink()
+	b := NewBufferedSink(stalled, 2, Drop)
+	b.Write(Record{Message: "1"})
+	// the goroutine holds the first record, two more fill the buffer
+	<-stalled.entered
+	for _, msg := range []string{"2", "3"} {
+		if err := b.Write(Record{Message: msg}); err != nil {
+			t.Fatalf("write %s to a buffer with room returned %v", msg, err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if err := b.Write(Record{Message: "dropped"}); err != ErrDropped {
+			t.Fatalf("write to a full buffer returned %v, want ErrDropped", err)
+		}
+	}
+	if b.Dropped() != 5 {
+		t.Errorf("Dropped is %d, want 5", b.Dropped())
+	}
+
+	close(stalled.release)
+	b.Close()
+	if lines := strings.Join(stalled.lines(), ","); lines != "1,2,3" {
+		t.Errorf("sink got %s, want 1,2,3", lines)
+	}
+}
+
+func TestBufferedSinkBlocks(t *testing.T) {
+	stalled := newGateSink()
+	b := NewBufferedSink(stalled, 1, Block)
+	b.Write(Record{Message: "1"})
+	<-stalled.entered
+	b.Write(Record{Message: "2"})
+
+	written := make(chan error)
+	go func() {
+		written <- b.Write(Record{Message: "3"})
+	}()
+	select {
+	case err := <-written:
+		t.Fatalf("write to a full blocking buffer returned %v before there was room", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(stalled.release)
+	if err := <-written; err != nil {
+		t.Errorf("blocked write returned %v", err)
+	}
+	b.Close()
+	if lines := strings.Join(stalled.lines(), ","); lines != "1,2,3" || b.Dropped() != 0 {
+		t.Errorf("sink got %s with %d dropped, want 1,2,3 and none", lines, b.Dropped())
+	}
+}
+
+func TestSyslogFormat(t *testing.T) {
+	sink, err := NewSyslogSink("udp", "127.0.0.1:514", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.hostname = "host"
+	at := time.Date(2026, 10, 19, 10, 4, 5, 123456789, time.FixedZone("", 2*60*60))
+	pid := strconv.Itoa(os.Getpid())
+
+	for _, test := range []struct {
+		level   Level
+		message string
+		want    string
+	}{
+		// PRI is facility user (1) * 8 + severity
+		{LevelDebug, "debug", "<15>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - debug"},
+		{LevelInfo, "info", "<14>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - info"},
+		{LevelWarn, "warn", "<12>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - warn"},
+		{LevelError, "error\n", "<11>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - error"},
+		{LevelFatal, "fatal", "<10>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - fatal"},
+		{Level(42), "unknown level", "<14>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + " - - unknown level"},
+		// the structured data is the nil value, brackets and quotes of the message are not parsed as structured data
+		{LevelInfo, `[meta key="a\"]b"] text`, "<14>1 2026-10-19T10:04:05.123456+02:00 host - " + pid + ` - - [meta key="a\"]b"] text`},
+	} {
+		if got := sink.format(Record{Time: at, Level: test.level, Message: test.message}); got != test.want {
+			t.Errorf("format of %q is\n%q, want\n%q", test.message, got, test.want)
+		}
+	}
+
+	// a whole second has no fraction, UTC is Z
+	if got := sink.format(Record{Time: time.Date(2026, 10, 19, 8, 4, 5, 0, time.UTC), Level: LevelInfo, Message: "m"}); !strings.HasPrefix(got, "<14>1 2026-10-19T08:04:05Z host ") {
+		t.Errorf("format is %q", got)
+	}
+}
+
+func TestSyslogFramingCountsBytes(t *testing.T) {
+	ln, messages, _ := fakeCollector(t, "tcp", "127.0.0.1:0")
+	sink, err := NewSyslogSink("tcp", ln.Addr().String(), "weblog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	// the octet count is in bytes, a multi-byte message must not cut into the next frame
+	for _, msg := range []string{"héllo wörld 日本", "next 1 message"} {
+		if err := sink.Write(Record{Time: time.Now(), Level: LevelInfo, Message: msg}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []string{" - - héllo wörld 日本", " - - next 1 message"} {
+		if msg := receive(t, messages); !strings.HasPrefix(msg, "<14>1 ") || !strings.HasSuffix(msg, want) {
+			t.Errorf("collector received %q, want a message ending with %q", msg, want)
+		}
+	}
+}
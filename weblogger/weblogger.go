@@ -1,61 +1,329 @@
 package weblogger
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Weblogger is logger with attributes
 type Weblogger struct {
-	Logger    *log.Logger
-	ClientIP  string
-	RPCmethod string
+	// ClientIP of the lines not written for a request, set before the server starts.
+	// The lines of a request are written with the Entry returned by With.
+	ClientIP string
+	// OnWriteError is called when a log line cannot be written
+	OnWriteError func(err error)
+	minLevel     int32
+	mu           sync.Mutex
+	// the log files, replaced by routes for the routed levels
+	defaults []Sink
+	// extra outputs such as stdout or syslog, for every level
+	sinks  []Sink
+	routes map[Level][]Sink
+}
+
+// Level is the severity of a log line
+type Level int32
+
+// severity levels, from the least to the most severe
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelNames = []string{"debug", "info", "warn", "error", "fatal"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelFatal {
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel converts debug, info, warn, error or fatal to a Level
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", s)
 }
 
 // severity tag
 const (
+	tagDebug = " DEBUG"
+	tagInfo  = " INFO"
+	tagWarn  = " WARN"
 	tagError = " ERROR"
 	tagFatal = " FATAL"
 )
 
+var levelTags = []string{tagDebug, tagInfo, tagWarn, tagError, tagFatal}
+
+// exit is called after a fatal line is written
+var exit = os.Exit
+
 func isLogFileExist(filePath string) (*os.File, error) {
 	logfile, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
 	return logfile, err
 }
 
+// SetLevel sets the minimum level written, lines below it are dropped
+func (w *Weblogger) SetLevel(level Level) {
+	atomic.StoreInt32(&w.minLevel, int32(level))
+}
+
+// Enabled reports whether lines of the level are written
+func (w *Weblogger) Enabled(level Level) bool {
+	return int32(level) >= atomic.LoadInt32(&w.minLevel)
+}
+
+// AddDefaultSink sends the lines whose level is not routed to the sink, e.g. the log file
+func (w *Weblogger) AddDefaultSink(sink Sink) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.defaults = append(w.defaults, sink)
+}
+
+// AddSink sends every line to the sink as well, whether its level is routed or not
+func (w *Weblogger) AddSink(sink Sink) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sinks = append(w.sinks, sink)
+}
+
+// Route sends the lines of the levels to the sink instead of the sinks added by AddDefaultSink
+func (w *Weblogger) Route(sink Sink, levels ...Level) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.routes == nil {
+		w.routes = make(map[Level][]Sink)
+	}
+	for _, level := range levels {
+		w.routes[level] = append(w.routes[level], sink)
+	}
+}
+
+// Get the sinks of a level
+func (w *Weblogger) sinksOf(level Level) []Sink {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sinks, ok := w.routes[level]
+	if !ok {
+		sinks = w.defaults
+	}
+	return append(sinks[:len(sinks):len(sinks)], w.sinks...)
+}
+
+// Entry writes the lines of one request with its client IP, principal and trace ID,
+// so concurrent requests do not share them through the Weblogger
+type Entry struct {
+	w         *Weblogger
+	ClientIP  string
+	Principal string
+	TraceID   string
+}
+
+// With returns an Entry writing to w, an empty clientIP is the ClientIP of w
+func (w *Weblogger) With(clientIP string, principal string, traceID string) *Entry {
+	if clientIP == "" {
+		clientIP = w.ClientIP
+	}
+	return &Entry{w: w, ClientIP: clientIP, Principal: principal, TraceID: traceID}
+}
+
+// Get the Entry of the lines not written for a request
+func (w *Weblogger) entry() *Entry {
+	return &Entry{w: w, ClientIP: w.ClientIP}
+}
+
+// write a log line of the level with the trace ID
+func (e *Entry) println(level Level, v ...interface{}) {
+	if e.TraceID != "" {
+		v = append(v, "traceID="+e.TraceID)
+	}
+	e.w.println(level, v...)
+}
+
+// write a log line of the level to its sinks and report a failed write to OnWriteError
+func (w *Weblogger) println(level Level, v ...interface{}) {
+	if !w.Enabled(level) {
+		return
+	}
+	sinks := w.sinksOf(level)
+	// before InitWebLogger, e.g. while reading the config, lines go to stderr
+	if len(sinks) == 0 {
+		log.Println(v...)
+		return
+	}
+	w.write(sinks, level, v)
+}
+
+// write one record to the sinks
+func (w *Weblogger) write(sinks []Sink, level Level, v []interface{}) {
+	record := Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: strings.TrimSuffix(fmt.Sprintln(v...), "\n"),
+	}
+	for _, sink := range sinks {
+		if err := sink.Write(record); err != nil && w.OnWriteError != nil {
+			w.OnWriteError(err)
+		}
+	}
+}
+
 // InitWebLogger is to init a web logger
 func (w *Weblogger) InitWebLogger(filePath string) {
-	logfile, err := isLogFileExist(filePath)
+	sink, err := NewFileSink(filePath)
 	if err != nil {
 		w.ServerFatalPrintln("File open error", err)
 	}
-	w.Logger = log.New(logfile, time.Now().Format("2006-01-02T15:04:05.99-07:00")+" ", 0)
+	w.AddDefaultSink(sink)
+}
+
+// Close flushes and closes the sinks, later lines go to stderr
+func (w *Weblogger) Close() error {
+	w.mu.Lock()
+	sinks := append(w.defaults, w.sinks...)
+	for _, routed := range w.routes {
+		sinks = append(sinks, routed...)
+	}
+	w.defaults = nil
+	w.sinks = nil
+	w.routes = nil
+	w.mu.Unlock()
+
+	var firstErr error
+	closed := make(map[Sink]bool)
+	for _, sink := range sinks {
+		if closed[sink] {
+			continue
+		}
+		closed[sink] = true
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// write a leveled line with its tag, the caller's file and line
+func (e *Entry) leveled(level Level, rpcMethod string, v []interface{}) {
+	if !e.w.Enabled(level) {
+		return
+	}
+	_, fileName, line, _ := runtime.Caller(2)
+	fields := []interface{}{levelTags[level], e.ClientIP, rpcMethod, fileName, line}
+	e.println(level, append(fields, v...)...)
+}
+
+// Debug print to the log with DEBUG message
+func (e *Entry) Debug(rpcMethod string, v ...interface{}) {
+	e.leveled(LevelDebug, rpcMethod, v)
+}
+
+// Info print to the log with INFO message
+func (e *Entry) Info(rpcMethod string, v ...interface{}) {
+	e.leveled(LevelInfo, rpcMethod, v)
+}
+
+// Warn print to the log with WARN message
+func (e *Entry) Warn(rpcMethod string, v ...interface{}) {
+	e.leveled(LevelWarn, rpcMethod, v)
+}
+
+// Error print to the log with ERROR message
+func (e *Entry) Error(rpcMethod string, v ...interface{}) {
+	e.leveled(LevelError, rpcMethod, v)
+}
+
+// Fatal print to the log with FATAL message, then closes the log files and exits
+func (e *Entry) Fatal(rpcMethod string, v ...interface{}) {
+	e.leveled(LevelFatal, rpcMethod, v)
+	e.w.Close()
+	exit(1)
 }
 
 // AccessPrintln print to the accessLog with access message
-func (w *Weblogger) AccessPrintln(rpcMethod string, para string) {
-	w.RPCmethod = rpcMethod
-	w.Logger.Println(w.ClientIP, w.RPCmethod, para)
+func (e *Entry) AccessPrintln(rpcMethod string, para string) {
+	fields := []interface{}{e.ClientIP}
+	if e.Principal != "" {
+		fields = append(fields, e.Principal)
+	}
+	e.println(LevelInfo, append(fields, rpcMethod, para)...)
 }
 
 // ErrorPrintln print to the errorLog with ERROR message
-func (w *Weblogger) ErrorPrintln(rpcMethod string, s string) {
-	w.RPCmethod = rpcMethod
+func (e *Entry) ErrorPrintln(rpcMethod string, s string) {
 	_, fileName, line, _ := runtime.Caller(1)
-	w.Logger.Println(tagError, w.ClientIP, w.RPCmethod, fileName, line, s)
+	e.println(LevelError, tagError, e.ClientIP, rpcMethod, fileName, line, s)
+}
+
+// Debug print to the log with DEBUG message
+func (w *Weblogger) Debug(rpcMethod string, v ...interface{}) {
+	w.entry().leveled(LevelDebug, rpcMethod, v)
+}
+
+// Info print to the log with INFO message
+func (w *Weblogger) Info(rpcMethod string, v ...interface{}) {
+	w.entry().leveled(LevelInfo, rpcMethod, v)
+}
+
+// Warn print to the log with WARN message
+func (w *Weblogger) Warn(rpcMethod string, v ...interface{}) {
+	w.entry().leveled(LevelWarn, rpcMethod, v)
+}
+
+// Error print to the log with ERROR message
+func (w *Weblogger) Error(rpcMethod string, v ...interface{}) {
+	w.entry().leveled(LevelError, rpcMethod, v)
+}
+
+// Fatal print to the log with FATAL message, then closes the log files and exits
+func (w *Weblogger) Fatal(rpcMethod string, v ...interface{}) {
+	w.entry().leveled(LevelFatal, rpcMethod, v)
+	w.Close()
+	exit(1)
+}
+
+// AccessPrintln print to the accessLog with access message
+func (w *Weblogger) AccessPrintln(rpcMethod string, para string) {
+	w.println(LevelInfo, w.ClientIP, rpcMethod, para)
 }
 
-// FatalPrintln print to the errorLog with FATAL message
-func (w *Weblogger) FatalPrintln(rpcMethod string, s string, err error) {
-	w.RPCmethod = rpcMethod
+// Access print to the accessLog with the given fields, the shared attributes are not used
+func (w *Weblogger) Access(v ...interface{}) {
+	if !w.Enabled(LevelInfo) {
+		return
+	}
+	sinks := w.sinksOf(LevelInfo)
+	if len(sinks) == 0 {
+		log.Println(v...)
+		return
+	}
+	w.write(sinks, LevelInfo, v)
+}
+
+// ErrorPrintln print to the errorLog with ERROR message
+func (w *Weblogger) ErrorPrintln(rpcMethod string, s string) {
 	_, fileName, line, _ := runtime.Caller(1)
-	w.Logger.Println(tagFatal, w.ClientIP, w.RPCmethod, fileName, line, s, err)
+	w.println(LevelError, tagError, w.ClientIP, rpcMethod, fileName, line, s)
 }
 
-// ServerFatalPrintln print to the errorLog with FATAL message
+// ServerFatalPrintln print to the errorLog with FATAL message, then closes the log files and exits
 func (w *Weblogger) ServerFatalPrintln(s string, err error) {
 	_, fileName, line, _ := runtime.Caller(1)
-	w.Logger.Println(tagFatal, fileName, line, s, err)
+	w.println(LevelFatal, tagFatal, fileName, line, s, err)
+	w.Close()
+	exit(1)
 }
@@ -0,0 +1,49 @@
+package weblogger
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEntriesOfConcurrentRequests(t *testing.T) {
+	var w Weblogger
+	w.ClientIP = "ClientIP is NOT existed."
+	sink := &memSink{}
+	w.AddDefaultSink(sink)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := strconv.Itoa(i)
+			entry := w.With("10.0.0."+id+":1234", "user"+id, "trace"+id)
+			for j := 0; j < 50; j++ {
+				entry.Error("Method", "request", id)
+			}
+		}(i)
+	}
+	w.Error("Method", "server")
+	wg.Wait()
+
+	lines := sink.lines()
+	if len(lines) != 20*50+1 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if fields[len(fields)-1] == "server" {
+			if !strings.Contains(line, "ClientIP is NOT existed.") || strings.Contains(line, "traceID=") {
+				t.Errorf("server line %q", line)
+			}
+			continue
+		}
+		// ERROR clientIP method file line request id traceID=...
+		id := fields[len(fields)-2]
+		if fields[1] != "10.0.0."+id+":1234" || fields[len(fields)-1] != "traceID=trace"+id {
+			t.Errorf("line of request %s has the fields of another request: %q", id, line)
+		}
+	}
+}
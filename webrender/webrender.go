@@ -0,0 +1,204 @@
+package webrender
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// content formats of an article, plain when empty
+const (
+	Plain    = "plain"
+	Markdown = "markdown"
+	HTML     = "html"
+)
+
+// runes of text in an excerpt
+const ExcerptRunes = 200
+
+// GitHub flavored Markdown, raw HTML is kept and removed by the sanitizer like the html format
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+// the elements and attributes allowed in rendered HTML, scripts, styles and event handlers are removed
+var sanitizer = newSanitizer()
+
+// Allow user generated content and the language class of fenced code blocks
+func newSanitizer() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return policy
+}
+
+// Heading is a table of contents entry
+type Heading struct {
+	// 1 to 6
+	Level int
+	// id of the heading element, the anchor of the entry
+	ID   string
+	Text string
+}
+
+// Rendered is the sanitized HTML of a content with its table of contents and excerpt
+type Rendered struct {
+	HTML    string
+	TOC     []Heading
+	Excerpt string
+}
+
+// ValidFormat reports whether format is a content format, empty is plain
+func ValidFormat(format string) bool {
+	switch format {
+	case "", Plain, Markdown, HTML:
+		return true
+	}
+	return false
+}
+
+// Render converts content to HTML in its format, sanitizes it, then gives the headings ids for the table of contents
+func Render(content string, format string) (*Rendered, error) {
+	var unsafe bytes.Buffer
+	switch format {
+	case "", Plain:
+		for _, paragraph := range strings.Split(content, "\n\n") {
+			if strings.TrimSpace(paragraph) == "" {
+				continue
+			}
+			unsafe.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n") + "</p>\n")
+		}
+	case Markdown:
+		if err := markdown.Convert([]byte(content), &unsafe); err != nil {
+			return nil, err
+		}
+	case HTML:
+		unsafe.WriteString(content)
+	default:
+		return nil, fmt.Errorf("unknown content format %q", format)
+	}
+
+	body := &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(sanitizer.Sanitize(unsafe.String())), body)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rendered{}
+	ids := make(map[string]int)
+	var text strings.Builder
+	var out bytes.Buffer
+	for _, node := range nodes {
+		r.walk(node, ids, &text)
+		if err := nethtml.Render(&out, node); err != nil {
+			return nil, err
+		}
+	}
+	r.HTML = out.String()
+	r.Excerpt = Excerpt(text.String(), ExcerptRunes)
+	return r, nil
+}
+
+// Give each heading a unique id and a table of contents entry, and collect the text of the other elements
+func (r *Rendered) walk(node *nethtml.Node, ids map[string]int, text *strings.Builder) {
+	if level := headingLevel(node); level != 0 {
+		title := strings.Join(strings.Fields(textOf(node)), " ")
+		id := Slug(title)
+		if id == "" {
+			id = "section"
+		}
+		if n := ids[id]; n != 0 {
+			ids[id] = n + 1
+			id += "-" + strconv.Itoa(n)
+		} else {
+			ids[id] = 1
+		}
+		setAttr(node, "id", id)
+		r.TOC = append(r.TOC, Heading{Level: level, ID: id, Text: title})
+		return
+	}
+	if node.Type == nethtml.TextNode {
+		text.WriteString(node.Data)
+		text.WriteString(" ")
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		r.walk(child, ids, text)
+	}
+}
+
+// Get 1 to 6 for h1 to h6, 0 for other nodes
+func headingLevel(node *nethtml.Node) int {
+	if node.Type != nethtml.ElementNode {
+		return 0
+	}
+	switch node.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return int(node.Data[1] - '0')
+	}
+	return 0
+}
+
+// Get the text of a node and its children
+func textOf(node *nethtml.Node) string {
+	if node.Type == nethtml.TextNode {
+		return node.Data
+	}
+	var text strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		text.WriteString(textOf(child))
+	}
+	return text.String()
+}
+
+// Set or replace an attribute of an element
+func setAttr(node *nethtml.Node, key string, value string) {
+	for i := range node.Attr {
+		if node.Attr[i].Key == key {
+			node.Attr[i].Val = value
+			return
+		}
+	}
+	node.Attr = append(node.Attr, nethtml.Attribute{Key: key, Val: value})
+}
+
+// Slug makes an anchor or a file name of a heading or a tag: lower case letters and digits separated by -
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(s) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if dash && b.Len() != 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(c)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// Excerpt cuts the text to at most n runes at a word boundary
+func Excerpt(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)[:n]
+	if i := strings.LastIndexByte(string(runes), ' '); i > 0 {
+		return string(runes)[:i] + "…"
+	}
+	return string(runes) + "…"
+}
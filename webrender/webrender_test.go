@@ -0,0 +1,121 @@
+package webrender
+
+import (
+	"strings"
+	"testing"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// Parse rendered HTML and call visit for every element
+func eachElement(t *testing.T, s string, visit func(node *nethtml.Node)) {
+	t.Helper()
+	doc, err := nethtml.Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var walk func(node *nethtml.Node)
+	walk = func(node *nethtml.Node) {
+		if node.Type == nethtml.ElementNode {
+			visit(node)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+}
+
+// Check that the HTML has no script or style element, no event handler and no javascript: URL
+func checkSafe(t *testing.T, name string, s string) {
+	t.Helper()
+	eachElement(t, s, func(node *nethtml.Node) {
+		switch node.Data {
+		case "script", "style", "iframe", "object", "embed":
+			t.Errorf("%s: <%s> kept in %q", name, node.Data, s)
+		}
+		for _, attr := range node.Attr {
+			if strings.HasPrefix(strings.ToLower(attr.Key), "on") {
+				t.Errorf("%s: event handler %s kept in %q", name, attr.Key, s)
+			}
+			if value := strings.ToLower(strings.TrimSpace(attr.Val)); strings.HasPrefix(value, "javascript:") {
+				t.Errorf("%s: %s=%q kept in %q", name, attr.Key, attr.Val, s)
+			}
+		}
+	})
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		format  string
+		keep    string
+	}{
+		{"script in html", `<p>text</p><script>alert(1)</script>`, HTML, "text"},
+		{"img onerror in html", `<img src="x.png" onerror="alert(1)">`, HTML, "x.png"},
+		{"javascript link in html", `<a href="javascript:alert(1)">link</a>`, HTML, "link"},
+		{"style in html", `<style>body{display:none}</style><p style="color:red" onclick="alert(1)">text</p>`, HTML, "text"},
+		{"iframe in html", `<iframe src="https://evil.example"></iframe><p>text</p>`, HTML, "text"},
+		{"javascript link in markdown", `[x](javascript:alert(1))`, Markdown, "x"},
+		{"raw html block in markdown", "text\n\n<script>alert(1)</script>\n\n<div onmouseover=\"alert(1)\">div</div>", Markdown, "div"},
+		{"inline html in markdown", `text <img src=x onerror=alert(1)> more`, Markdown, "more"},
+		{"html in plain", `<script>alert(1)</script>`, Plain, "&lt;script&gt;"},
+	} {
+		r, err := Render(test.content, test.format)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		checkSafe(t, test.name, r.HTML)
+		if strings.Contains(r.HTML, "alert(1)") && test.format != Plain {
+			t.Errorf("%s: script text kept in %q", test.name, r.HTML)
+		}
+		if !strings.Contains(r.HTML, test.keep) {
+			t.Errorf("%s: %q lost from %q", test.name, test.keep, r.HTML)
+		}
+	}
+}
+
+func TestHeadingIDsCannotInjectAttributes(t *testing.T) {
+	for _, test := range []struct {
+		content string
+		format  string
+		id      string
+	}{
+		{`# Title" onmouseover="alert(1)`, Markdown, "title-onmouseover-alert-1"},
+		{`<h2 id="x" onclick="alert(1)">A &quot;quoted&quot; &lt;b&gt;</h2>`, HTML, "a-quoted-b"},
+		{`<h3 id="javascript:alert(1)"></h3>`, HTML, "section"},
+	} {
+		r, err := Render(test.content, test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSafe(t, test.content, r.HTML)
+		eachElement(t, r.HTML, func(node *nethtml.Node) {
+			if headingLevel(node) == 0 {
+				return
+			}
+			if len(node.Attr) != 1 || node.Attr[0].Key != "id" || node.Attr[0].Val != test.id {
+				t.Errorf("%q: heading has attributes %v, want only id=%q", test.content, node.Attr, test.id)
+			}
+		})
+		if len(r.TOC) != 1 || r.TOC[0].ID != test.id {
+			t.Errorf("%q: table of contents is %+v", test.content, r.TOC)
+		}
+	}
+}
+
+func TestHeadingIDsAreUnique(t *testing.T) {
+	r, err := Render("# Intro\n\n## Intro\n\n## Intro", Markdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, heading := range r.TOC {
+		ids = append(ids, heading.ID)
+	}
+	if strings.Join(ids, ",") != "intro,intro-1,intro-2" {
+		t.Errorf("heading ids are %v", ids)
+	}
+}